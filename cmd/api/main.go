@@ -4,8 +4,8 @@ import (
 	"github.com/winnamu6/go-subscription-service/docs"
 	"github.com/winnamu6/go-subscription-service/internal/config"
 	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/handler"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
-	"github.com/winnamu6/go-subscription-service/internal/model"
 	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
 	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
 	"github.com/winnamu6/go-subscription-service/internal/router"
@@ -24,7 +24,7 @@ func main() {
 	log.Info("Logger initialized")
 
 	cfg := config.Load()
-	log.Infof("Configuration loaded: %+v", cfg)
+	log.Info("Configuration loaded")
 
 	docs.SwaggerInfo.Title = "Subscription Service API"
 	docs.SwaggerInfo.Description = "API documentation for Subscription Service"
@@ -37,11 +37,59 @@ func main() {
 
 	readRepo := read_repository.NewSubscriptionReadRepo(database)
 	writeRepo := write_repository.NewSubscriptionWriteRepo(database)
+	rateReadRepo := read_repository.NewExchangeRateReadRepo(database)
+	rateWriteRepo := write_repository.NewExchangeRateWriteRepo(database)
+	chargeReadRepo := read_repository.NewChargeReadRepo(database)
+	priceReadRepo := read_repository.NewSubscriptionPriceReadRepo(database)
+	pauseReadRepo := read_repository.NewSubscriptionPauseReadRepo(database)
+	cancelReadRepo := read_repository.NewSubscriptionCancellationReadRepo(database)
+	priceWriteRepo := write_repository.NewSubscriptionPriceWriteRepo(database)
+	pauseWriteRepo := write_repository.NewSubscriptionPauseWriteRepo(database)
+	cancelWriteRepo := write_repository.NewSubscriptionCancellationWriteRepo(database)
+	auditReadRepo := read_repository.NewAuditReadRepo(database)
+	auditWriteRepo := write_repository.NewAuditWriteRepo(database)
+	webhookReadRepo := read_repository.NewWebhookReadRepo(database)
+	webhookWriteRepo := write_repository.NewWebhookWriteRepo(database)
+	outboxRepo := write_repository.NewOutboxWriteRepo(database)
+	idempotencyRepo := write_repository.NewIdempotencyKeyWriteRepo(database)
+	calendarReadRepo := read_repository.NewCalendarTokenReadRepo(database)
+	calendarWriteRepo := write_repository.NewCalendarTokenWriteRepo(database)
+	notificationReadRepo := read_repository.NewNotificationReadRepo(database)
+	notificationWriteRepo := write_repository.NewNotificationWriteRepo(database)
+	tx := write_repository.NewTransactor(database)
+	serviceReadRepo := read_repository.NewServiceReadRepo(database)
+	serviceWriteRepo := write_repository.NewServiceWriteRepo(database)
 
-	readSvc := service.NewSubscriptionQueryService(readRepo)
-	writeSvc := service.NewSubscriptionCommandService(writeRepo, readSvc)
+	rateReadSvc := service.NewExchangeRateQueryService(rateReadRepo)
+	rateWriteSvc := service.NewExchangeRateCommandService(rateWriteRepo)
+	catalogSvc := service.NewCatalogService(serviceReadRepo, serviceWriteRepo, writeRepo, auditWriteRepo, outboxRepo, tx)
+	readSvc := service.NewSubscriptionQueryService(readRepo, priceReadRepo, pauseReadRepo, rateReadSvc, catalogSvc)
+	webhookSvc := service.NewWebhookService(webhookReadRepo, webhookWriteRepo)
+	writeSvc := service.NewSubscriptionCommandService(writeRepo, priceWriteRepo, pauseWriteRepo, cancelWriteRepo, auditWriteRepo, outboxRepo, tx, readSvc, catalogSvc)
+	importSvc := service.NewSubscriptionImportService(writeSvc, readRepo, catalogSvc, tx, handler.RequestValidator{})
+	chargeSvc := service.NewChargeQueryService(chargeReadRepo)
+	cancellationSvc := service.NewCancellationQueryService(cancelReadRepo)
+	auditSvc := service.NewAuditQueryService(auditReadRepo)
+	idempotencySvc := service.NewIdempotencyService(idempotencyRepo, tx, cfg.IdempotencyTTL)
+	calendarSvc := service.NewCalendarService(calendarReadRepo, calendarWriteRepo, readSvc)
+	// reminders are sent by cmd/worker, the API only manages preferences
+	notificationSvc := service.NewNotificationService(notificationReadRepo, notificationWriteRepo, readSvc, nil, nil, cfg.ReminderDaysAhead)
 
-	r := router.NewRouter(readSvc, writeSvc)
+	r := router.NewRouter(cfg, router.Services{
+		SubscriptionQuery:   readSvc,
+		SubscriptionCommand: writeSvc,
+		SubscriptionImport:  importSvc,
+		ExchangeRateQuery:   rateReadSvc,
+		ExchangeRateCommand: rateWriteSvc,
+		ChargeQuery:         chargeSvc,
+		AuditQuery:          auditSvc,
+		Idempotency:         idempotencySvc,
+		Calendar:            calendarSvc,
+		Webhooks:            webhookSvc,
+		Notifications:       notificationSvc,
+		CancellationQuery:   cancellationSvc,
+		Catalog:             catalogSvc,
+	})
 
 	r.GET("/", func(c *gin.Context) {
 		c.Redirect(302, "/swagger/index.html")
@@ -55,10 +103,10 @@ func main() {
 	}
 }
 
-func runMigrations(db *gorm.DB) {
+func runMigrations(database *gorm.DB) {
 	log := logger.Get()
 	log.Info("Running migrations...")
-	if err := db.AutoMigrate(&model.Subscription{}); err != nil {
+	if err := db.Migrate(database); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 	log.Info("Migrations completed successfully")
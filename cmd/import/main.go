@@ -0,0 +1,129 @@
+// Command import loads subscriptions from a CSV or JSON Lines file, with the
+// same validation and report as POST /subscriptions/import.
+//
+//	go run ./cmd/import -mode partial subscriptions.csv
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/config"
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/handler"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+	"github.com/winnamu6/go-subscription-service/internal/requestmeta"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+func main() {
+	format := flag.String("format", "", "file format: csv or ndjson (default: by file extension)")
+	mode := flag.String("mode", model.ImportModeAtomic, "atomic or partial")
+	dryRun := flag.Bool("dry-run", false, "validate only, create nothing")
+	actor := flag.String("actor", "import-cli", "actor recorded in the audit log")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] FILE\n\nFILE may be - to read from stdin.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	if *format == "" {
+		*format = formatByExtension(path)
+	}
+	if *mode != model.ImportModeAtomic && *mode != model.ImportModePartial {
+		fmt.Fprintf(os.Stderr, "unknown mode %q\n", *mode)
+		os.Exit(2)
+	}
+
+	logger.Init()
+	log := logger.Get()
+	// stdout is reserved for the report.
+	log.SetOutput(os.Stderr)
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", path, err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	cfg := config.Load()
+	database := db.Connect(cfg)
+	if err := db.Migrate(database); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	readRepo := read_repository.NewSubscriptionReadRepo(database)
+	writeRepo := write_repository.NewSubscriptionWriteRepo(database)
+	rateReadRepo := read_repository.NewExchangeRateReadRepo(database)
+	priceReadRepo := read_repository.NewSubscriptionPriceReadRepo(database)
+	pauseReadRepo := read_repository.NewSubscriptionPauseReadRepo(database)
+	priceWriteRepo := write_repository.NewSubscriptionPriceWriteRepo(database)
+	pauseWriteRepo := write_repository.NewSubscriptionPauseWriteRepo(database)
+	cancelWriteRepo := write_repository.NewSubscriptionCancellationWriteRepo(database)
+	auditWriteRepo := write_repository.NewAuditWriteRepo(database)
+	outboxRepo := write_repository.NewOutboxWriteRepo(database)
+	tx := write_repository.NewTransactor(database)
+	serviceReadRepo := read_repository.NewServiceReadRepo(database)
+	serviceWriteRepo := write_repository.NewServiceWriteRepo(database)
+
+	rateReadSvc := service.NewExchangeRateQueryService(rateReadRepo)
+	catalogSvc := service.NewCatalogService(serviceReadRepo, serviceWriteRepo, writeRepo, auditWriteRepo, outboxRepo, tx)
+	readSvc := service.NewSubscriptionQueryService(readRepo, priceReadRepo, pauseReadRepo, rateReadSvc, catalogSvc)
+	writeSvc := service.NewSubscriptionCommandService(writeRepo, priceWriteRepo, pauseWriteRepo, cancelWriteRepo, auditWriteRepo, outboxRepo, tx, readSvc, catalogSvc)
+
+	handler.UseRequestFieldNames()
+	importSvc := service.NewSubscriptionImportService(writeSvc, readRepo, catalogSvc, tx, handler.RequestValidator{})
+
+	ctx := requestmeta.With(context.Background(), requestmeta.Meta{
+		RequestID: uuid.NewString(),
+		Actor:     *actor,
+	})
+	res, err := importSvc.Import(ctx, in, model.ImportOptions{
+		Format: *format,
+		Mode:   *mode,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(res); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+	if res.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func formatByExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return model.ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return model.ImportFormatNDJSON
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/config"
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/events"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/notify"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+	"github.com/winnamu6/go-subscription-service/internal/scheduler"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+func main() {
+	logger.Init()
+	log := logger.Get()
+	log.Info("Logger initialized")
+
+	cfg := config.Load()
+	log.Info("Configuration loaded")
+
+	database := db.Connect(cfg)
+	if err := db.Migrate(database); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	subRepo := read_repository.NewSubscriptionReadRepo(database)
+	subWriteRepo := write_repository.NewSubscriptionWriteRepo(database)
+	chargeRepo := write_repository.NewChargeWriteRepo(database)
+	priceReadRepo := read_repository.NewSubscriptionPriceReadRepo(database)
+	pauseReadRepo := read_repository.NewSubscriptionPauseReadRepo(database)
+	priceRepo := write_repository.NewSubscriptionPriceWriteRepo(database)
+	pauseRepo := write_repository.NewSubscriptionPauseWriteRepo(database)
+	cancelRepo := write_repository.NewSubscriptionCancellationWriteRepo(database)
+	rateReadRepo := read_repository.NewExchangeRateReadRepo(database)
+	auditWriteRepo := write_repository.NewAuditWriteRepo(database)
+	idempotencyRepo := write_repository.NewIdempotencyKeyWriteRepo(database)
+	webhookReadRepo := read_repository.NewWebhookReadRepo(database)
+	webhookWriteRepo := write_repository.NewWebhookWriteRepo(database)
+	outboxRepo := write_repository.NewOutboxWriteRepo(database)
+	notificationReadRepo := read_repository.NewNotificationReadRepo(database)
+	notificationWriteRepo := write_repository.NewNotificationWriteRepo(database)
+	tx := write_repository.NewTransactor(database)
+	serviceReadRepo := read_repository.NewServiceReadRepo(database)
+	serviceWriteRepo := write_repository.NewServiceWriteRepo(database)
+
+	renewalSvc := service.NewRenewalService(subRepo, chargeRepo, tx)
+	rateReadSvc := service.NewExchangeRateQueryService(rateReadRepo)
+	catalogSvc := service.NewCatalogService(serviceReadRepo, serviceWriteRepo, subWriteRepo, auditWriteRepo, outboxRepo, tx)
+	readSvc := service.NewSubscriptionQueryService(subRepo, priceReadRepo, pauseReadRepo, rateReadSvc, catalogSvc)
+	webhookSvc := service.NewWebhookService(webhookReadRepo, webhookWriteRepo)
+	writeSvc := service.NewSubscriptionCommandService(subWriteRepo, priceRepo, pauseRepo, cancelRepo, auditWriteRepo, outboxRepo, tx, readSvc, catalogSvc)
+	deliverySvc := service.NewWebhookDeliveryService(webhookReadRepo, webhookWriteRepo, cfg.WebhookTimeout, cfg.WebhookMaxAttempts)
+
+	bus := events.NewBus()
+	for _, eventType := range model.WebhookEventTypes {
+		bus.Subscribe(eventType, webhookSvc.Enqueue)
+	}
+	publisher := events.Publishers{bus}
+	if cfg.EventSink != "" {
+		sink, err := events.OpenSink(cfg.EventSink)
+		if err != nil {
+			log.Fatalf("Failed to open event sink %s: %v", cfg.EventSink, err)
+		}
+		defer sink.Close()
+		publisher = append(publisher, sink)
+	}
+	relaySvc := service.NewOutboxRelayService(outboxRepo, tx, publisher, cfg.OutboxMaxAttempts)
+
+	templates, err := notify.LoadTemplates(cfg.ReminderTemplatesDir)
+	if err != nil {
+		log.Fatalf("Failed to load reminder templates: %v", err)
+	}
+	mailer := notify.NewSMTPMailer(notify.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUser,
+		Password: cfg.SMTPPass,
+		From:     cfg.SMTPFrom,
+	})
+	notificationSvc := service.NewNotificationService(notificationReadRepo, notificationWriteRepo, readSvc, mailer, templates, cfg.ReminderDaysAhead)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	s := scheduler.New()
+	s.Every(cfg.RenewalInterval, "renewal", func(ctx context.Context, now time.Time) error {
+		_, err := renewalSvc.RenewDue(ctx, now)
+		return err
+	})
+	s.Every(cfg.RenewalInterval, "price-changes", func(ctx context.Context, now time.Time) error {
+		_, err := writeSvc.ApplyDuePrices(ctx, now)
+		return err
+	})
+	s.Every(cfg.RenewalInterval, "idempotency-keys-cleanup", func(ctx context.Context, now time.Time) error {
+		_, err := idempotencyRepo.DeleteExpired(ctx, now)
+		return err
+	})
+
+	s.Every(cfg.RenewalInterval, "expiry", func(ctx context.Context, now time.Time) error {
+		_, err := writeSvc.ExpireDue(ctx, now)
+		return err
+	})
+	s.Every(cfg.RenewalInterval, "trial-conversion", func(ctx context.Context, now time.Time) error {
+		_, err := writeSvc.ConvertTrials(ctx, now)
+		return err
+	})
+	s.Every(cfg.OutboxInterval, "outbox-relay", func(ctx context.Context, now time.Time) error {
+		_, err := relaySvc.RelayPending(ctx, now)
+		return err
+	})
+	s.Every(cfg.RenewalInterval, "outbox-cleanup", func(ctx context.Context, now time.Time) error {
+		_, err := relaySvc.PurgePublished(ctx, now.Add(-cfg.OutboxRetention))
+		return err
+	})
+	s.Every(cfg.ReminderInterval, "reminders", func(ctx context.Context, now time.Time) error {
+		_, err := notificationSvc.SendReminders(ctx, now)
+		return err
+	})
+	s.Every(cfg.WebhookInterval, "webhook-deliveries", func(ctx context.Context, now time.Time) error {
+		_, err := deliverySvc.DeliverDue(ctx, now)
+		return err
+	})
+
+	log.Infof("Starting worker | renewal_interval=%s outbox_interval=%s webhook_interval=%s reminder_interval=%s smtp=%s:%s event_sink=%q",
+		cfg.RenewalInterval, cfg.OutboxInterval, cfg.WebhookInterval, cfg.ReminderInterval, cfg.SMTPHost, cfg.SMTPPort, cfg.EventSink)
+	s.Run(ctx)
+}
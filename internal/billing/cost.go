@@ -0,0 +1,92 @@
+package billing
+
+import (
+	"math"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+// Charge is a single amount billed to a subscription on a billing date.
+type Charge struct {
+	Date     time.Time
+	Amount   model.Amount
+	Currency string
+}
+
+// BilledCharges returns the charges of sub whose billing date falls inside
+// [from, to]. Free periods, such as a trial without a price, and billing
+// dates during a pause are left out.
+func BilledCharges(sub *model.Subscription, from, to time.Time) []Charge {
+	var res []Charge
+	for _, p := range Periods(sub, from, to) {
+		if !p.Start.Before(from) && !sub.PausedOn(p.Start) {
+			price := p.Price(sub)
+			if price.Amount == 0 {
+				continue
+			}
+			res = append(res, Charge{Date: p.Start, Amount: price.Amount, Currency: price.Currency})
+		}
+	}
+	return res
+}
+
+// ProratedCharges charges every billing period in proportion to the part of
+// it that overlaps both [from, to] and the active range of sub, not counting
+// the time sub is paused.
+func ProratedCharges(sub *model.Subscription, from, to time.Time) []Charge {
+	if sub.EndDate != nil && sub.EndDate.Before(to) {
+		to = *sub.EndDate
+	}
+	var res []Charge
+	for _, p := range Periods(sub, from, to) {
+		overlap := p.overlap(from, to) - pausedDuring(sub, p, from, to)
+		if overlap <= 0 {
+			continue
+		}
+		price := p.Price(sub)
+		if price.Amount == 0 {
+			continue
+		}
+		amount := math.Round(float64(price.Amount) * float64(overlap) / float64(p.End.Sub(p.Start)))
+		res = append(res, Charge{Date: p.Start, Amount: model.Amount(amount), Currency: price.Currency})
+	}
+	return res
+}
+
+func Charges(sub *model.Subscription, from, to time.Time, mode model.SumMode) []Charge {
+	switch mode {
+	case model.SumModeProrated:
+		return ProratedCharges(sub, from, to)
+	case model.SumModeStartsOnly:
+		if !sub.StartDate.Before(from) && !sub.StartDate.After(to) && !sub.PausedOn(sub.StartDate) {
+			// the first period is the trial, if any, and a free trial costs nothing
+			first := Period{Start: sub.StartDate, Trial: sub.HasTrial()}
+			price := first.Price(sub)
+			if price.Amount == 0 {
+				return nil
+			}
+			return []Charge{{Date: sub.StartDate, Amount: price.Amount, Currency: price.Currency}}
+		}
+		return nil
+	default:
+		return BilledCharges(sub, from, to)
+	}
+}
+
+// pausedDuring returns how much of the period p within [from, to] sub spends
+// paused.
+func pausedDuring(sub *model.Subscription, p Period, from, to time.Time) time.Duration {
+	var paused time.Duration
+	for _, pause := range sub.PauseHistory() {
+		start, end := pause.PausedAt, p.End
+		if start.Before(p.Start) {
+			start = p.Start
+		}
+		if pause.ResumeAt != nil && pause.ResumeAt.Before(end) {
+			end = *pause.ResumeAt
+		}
+		paused += Period{Start: start, End: end}.overlap(from, to)
+	}
+	return paused
+}
@@ -0,0 +1,213 @@
+package billing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func monthly(start string, price model.Amount) model.Subscription {
+	return model.Subscription{
+		StartDate:       day(start),
+		Price:           price,
+		Currency:        model.CurrencyRUB,
+		BillingPeriod:   model.BillingPeriodMonthly,
+		BillingInterval: 1,
+	}
+}
+
+func TestCharges(t *testing.T) {
+	paused := monthly("2025-01-01", 3000)
+	paused.PausedAt, paused.ResumeAt = ptr(day("2025-02-01")), ptr(day("2025-03-01"))
+
+	shortPause := monthly("2025-02-01", 3000)
+	shortPause.PausedAt, shortPause.ResumeAt = ptr(day("2025-02-01")), ptr(day("2025-02-08"))
+
+	ended := monthly("2025-01-01", 1000)
+	ended.EndDate = ptr(day("2025-01-11"))
+
+	weekly := monthly("2025-01-01", 1)
+	weekly.BillingPeriod = model.BillingPeriodWeekly
+
+	freeTrial := monthly("2025-01-01", 1000)
+	freeTrial.TrialEndDate = ptr(day("2025-01-15"))
+
+	paidTrial := freeTrial
+	paidTrial.TrialPrice = 100
+
+	tests := []struct {
+		name     string
+		sub      model.Subscription
+		mode     model.SumMode
+		from, to time.Time
+		want     []Charge
+	}{
+		{
+			name: "billed month end anchor",
+			sub:  monthly("2025-01-31", 1000),
+			mode: model.SumModeBilled,
+			from: day("2025-02-01"), to: day("2025-04-29"),
+			want: []Charge{
+				{Date: day("2025-02-28"), Amount: 1000},
+				{Date: day("2025-03-31"), Amount: 1000},
+			},
+		},
+		{
+			name: "billed window bounds are inclusive",
+			sub:  monthly("2025-01-31", 1000),
+			mode: model.SumModeBilled,
+			from: day("2025-01-31"), to: day("2025-02-28"),
+			want: []Charge{
+				{Date: day("2025-01-31"), Amount: 1000},
+				{Date: day("2025-02-28"), Amount: 1000},
+			},
+		},
+		{
+			name: "billed skips dates during a pause",
+			sub:  paused,
+			mode: model.SumModeBilled,
+			from: day("2025-01-01"), to: day("2025-03-31"),
+			want: []Charge{
+				{Date: day("2025-01-01"), Amount: 3000},
+				{Date: day("2025-03-01"), Amount: 3000},
+			},
+		},
+		{
+			name: "billed skips a free trial",
+			sub:  freeTrial,
+			mode: model.SumModeBilled,
+			from: day("2025-01-01"), to: day("2025-02-14"),
+			want: []Charge{
+				{Date: day("2025-01-15"), Amount: 1000},
+			},
+		},
+		{
+			name: "billed charges a paid trial",
+			sub:  paidTrial,
+			mode: model.SumModeBilled,
+			from: day("2025-01-01"), to: day("2025-02-14"),
+			want: []Charge{
+				{Date: day("2025-01-01"), Amount: 100},
+				{Date: day("2025-01-15"), Amount: 1000},
+			},
+		},
+		{
+			name: "prorated part of a month",
+			sub:  monthly("2025-01-01", 1000),
+			mode: model.SumModeProrated,
+			from: day("2025-01-01"), to: day("2025-01-16"),
+			want: []Charge{
+				{Date: day("2025-01-01"), Amount: 484},
+			},
+		},
+		{
+			name: "prorated leap february",
+			sub:  monthly("2024-02-01", 2900),
+			mode: model.SumModeProrated,
+			from: day("2024-02-01"), to: day("2024-02-11"),
+			want: []Charge{
+				{Date: day("2024-02-01"), Amount: 1000},
+			},
+		},
+		{
+			name: "prorated rounds half away from zero",
+			sub:  weekly,
+			mode: model.SumModeProrated,
+			from: day("2025-01-01"), to: day("2025-01-01").Add(84 * time.Hour),
+			want: []Charge{
+				{Date: day("2025-01-01"), Amount: 1},
+			},
+		},
+		{
+			name: "prorated stops at end date",
+			sub:  ended,
+			mode: model.SumModeProrated,
+			from: day("2025-01-01"), to: day("2025-01-31"),
+			want: []Charge{
+				{Date: day("2025-01-01"), Amount: 323},
+			},
+		},
+		{
+			name: "prorated leaves out paused time",
+			sub:  shortPause,
+			mode: model.SumModeProrated,
+			from: day("2025-02-01"), to: day("2025-02-15"),
+			want: []Charge{
+				{Date: day("2025-02-01"), Amount: 750},
+			},
+		},
+		{
+			name: "starts only",
+			sub:  monthly("2025-01-10", 1000),
+			mode: model.SumModeStartsOnly,
+			from: day("2025-01-01"), to: day("2025-12-31"),
+			want: []Charge{
+				{Date: day("2025-01-10"), Amount: 1000},
+			},
+		},
+		{
+			name: "starts only outside the window",
+			sub:  monthly("2024-12-31", 1000),
+			mode: model.SumModeStartsOnly,
+			from: day("2025-01-01"), to: day("2025-12-31"),
+		},
+		{
+			name: "starts only with a free trial",
+			sub:  freeTrial,
+			mode: model.SumModeStartsOnly,
+			from: day("2025-01-01"), to: day("2025-12-31"),
+		},
+		{
+			name: "starts only with a paid trial",
+			sub:  paidTrial,
+			mode: model.SumModeStartsOnly,
+			from: day("2025-01-01"), to: day("2025-12-31"),
+			want: []Charge{
+				{Date: day("2025-01-01"), Amount: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Charges(&tt.sub, tt.from, tt.to, tt.mode)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Charges returned %d charges, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if !got[i].Date.Equal(tt.want[i].Date) || got[i].Amount != tt.want[i].Amount {
+					t.Errorf("charge %d = %s %d, want %s %d", i,
+						got[i].Date.Format(time.DateOnly), got[i].Amount, tt.want[i].Date.Format(time.DateOnly), tt.want[i].Amount)
+				}
+				if got[i].Currency != model.CurrencyRUB {
+					t.Errorf("charge %d currency = %q, want %q", i, got[i].Currency, model.CurrencyRUB)
+				}
+			}
+		})
+	}
+}
+
+func TestChargesUsePriceHistory(t *testing.T) {
+	sub := monthly("2025-01-01", 2000)
+	sub.Prices = []model.SubscriptionPrice{
+		{Amount: 1000, Currency: model.CurrencyRUB, EffectiveFrom: day("2025-01-01")},
+		{Amount: 2000, Currency: model.CurrencyUSD, EffectiveFrom: day("2025-03-01")},
+	}
+	got := BilledCharges(&sub, day("2025-02-01"), day("2025-03-31"))
+	want := []Charge{
+		{Date: day("2025-02-01"), Amount: 1000, Currency: model.CurrencyRUB},
+		{Date: day("2025-03-01"), Amount: 2000, Currency: model.CurrencyUSD},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("BilledCharges returned %d charges, want %d: %v", len(got), len(want), got)
+	}
+	for i := range got {
+		if !got[i].Date.Equal(want[i].Date) || got[i].Amount != want[i].Amount || got[i].Currency != want[i].Currency {
+			t.Errorf("charge %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
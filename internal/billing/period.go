@@ -0,0 +1,205 @@
+package billing
+
+import (
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type Period struct {
+	Start time.Time
+	End   time.Time
+	// Trial marks the trial period, which is charged the trial price once
+	// instead of the regular price.
+	Trial bool
+}
+
+// AddMonths shifts t by n calendar months, clamping the day to the last day of
+// the target month (Jan 31 + 1 month = Feb 28/29).
+func AddMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := daysIn(first); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
+
+// BillingStart returns the first regular billing date of sub: the end of its
+// trial or, without a trial, its start date.
+func BillingStart(sub *model.Subscription) time.Time {
+	if sub.HasTrial() {
+		return *sub.TrialEndDate
+	}
+	return sub.StartDate
+}
+
+// ChargeDate returns the n-th regular billing date of sub, counting from
+// BillingStart (n = 0). Month based periods are computed from that date every
+// time so that clamped days (Jan 31 -> Feb 28) do not drift.
+func ChargeDate(sub *model.Subscription, n int) time.Time {
+	start := BillingStart(sub)
+	interval := sub.BillingInterval
+	if interval < 1 {
+		interval = 1
+	}
+	switch sub.BillingPeriod {
+	case model.BillingPeriodWeekly:
+		return start.AddDate(0, 0, 7*interval*n)
+	case model.BillingPeriodQuarterly:
+		return AddMonths(start, 3*interval*n)
+	case model.BillingPeriodYearly:
+		return AddMonths(start, 12*interval*n)
+	case model.BillingPeriodCustom:
+		return start.AddDate(0, 0, interval*n)
+	default:
+		return AddMonths(start, interval*n)
+	}
+}
+
+// NextChargeDate returns the first regular billing date of sub after from
+// that does not fall on a pause. ok is false when the subscription ends
+// before that date or stays paused indefinitely.
+func NextChargeDate(sub *model.Subscription, from time.Time) (date time.Time, ok bool) {
+	for i := periodIndex(sub, from); ; i++ {
+		date = ChargeDate(sub, i)
+		if sub.EndDate != nil && !date.Before(*sub.EndDate) {
+			return time.Time{}, false
+		}
+		if !date.After(from) {
+			continue
+		}
+		pause := pauseOn(sub, date)
+		if pause == nil {
+			return date, true
+		}
+		if pause.ResumeAt == nil {
+			return time.Time{}, false
+		}
+		// skip the billing dates the pause covers
+		i = max(i, periodIndex(sub, *pause.ResumeAt)-1)
+	}
+}
+
+// PeriodEnd returns the end of the billing period of sub that includes t: the
+// first billing date after t. During a trial that is the end of the trial.
+func PeriodEnd(sub *model.Subscription, t time.Time) time.Time {
+	for i := periodIndex(sub, t); ; i++ {
+		if date := ChargeDate(sub, i); date.After(t) {
+			return date
+		}
+	}
+}
+
+// periodIndex returns the index n of the regular billing period of sub that
+// includes t, so that ChargeDate(sub, n) <= t < ChargeDate(sub, n+1), or 0 if
+// t is before BillingStart. n is estimated from the calendar distance and then
+// corrected for clamped month days, so the cost does not grow with the
+// distance between BillingStart and t.
+func periodIndex(sub *model.Subscription, t time.Time) int {
+	start := BillingStart(sub)
+	if !t.After(start) {
+		return 0
+	}
+	interval := max(sub.BillingInterval, 1)
+	days := int((t.Unix() - start.Unix()) / 86400)
+	months := (t.Year()-start.Year())*12 + int(t.Month()) - int(start.Month())
+
+	var n int
+	switch sub.BillingPeriod {
+	case model.BillingPeriodWeekly:
+		n = days / (7 * interval)
+	case model.BillingPeriodQuarterly:
+		n = months / (3 * interval)
+	case model.BillingPeriodYearly:
+		n = months / (12 * interval)
+	case model.BillingPeriodCustom:
+		n = days / interval
+	default:
+		n = months / interval
+	}
+	for n > 0 && ChargeDate(sub, n).After(t) {
+		n--
+	}
+	for !ChargeDate(sub, n+1).After(t) {
+		n++
+	}
+	return n
+}
+
+// pauseOn returns the pause of sub that t falls on, or nil.
+func pauseOn(sub *model.Subscription, t time.Time) *model.SubscriptionPause {
+	for _, p := range sub.PauseHistory() {
+		if p.Covers(t) {
+			return &p
+		}
+	}
+	return nil
+}
+
+// Periods returns the billing periods of sub that start inside its active
+// range [StartDate, EndDate), end after from and start no later than to. A
+// trial is the first period and lasts from StartDate to BillingStart. Periods
+// before from are skipped without being walked.
+func Periods(sub *model.Subscription, from, to time.Time) []Period {
+	var res []Period
+	if sub.HasTrial() {
+		p := Period{Start: sub.StartDate, End: *sub.TrialEndDate, Trial: true}
+		if p.Start.After(to) || (sub.EndDate != nil && !p.Start.Before(*sub.EndDate)) {
+			return res
+		}
+		if p.End.After(from) {
+			res = append(res, p)
+		}
+	}
+	for i := periodIndex(sub, from); ; i++ {
+		p := Period{Start: ChargeDate(sub, i), End: ChargeDate(sub, i+1)}
+		if p.Start.After(to) || (sub.EndDate != nil && !p.Start.Before(*sub.EndDate)) {
+			return res
+		}
+		res = append(res, p)
+	}
+}
+
+// Price returns what sub is charged for the period p.
+func (p Period) Price(sub *model.Subscription) model.Money {
+	if p.Trial {
+		return model.Money{Amount: sub.TrialPrice, Currency: sub.Currency}
+	}
+	return sub.PriceAt(p.Start)
+}
+
+func (p Period) overlap(from, to time.Time) time.Duration {
+	start, stop := p.Start, p.End
+	if from.After(start) {
+		start = from
+	}
+	if to.Before(stop) {
+		stop = to
+	}
+	if !stop.After(start) {
+		return 0
+	}
+	return stop.Sub(start)
+}
+
+// Months splits [from, to] into calendar month buckets. The first and the
+// last bucket are clipped to the window; each bucket ends one nanosecond
+// before the next one starts so that charges are never counted twice.
+func Months(from, to time.Time) []Period {
+	var res []Period
+	for start := from; !start.After(to); {
+		next := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location())
+		end := next.Add(-time.Nanosecond)
+		if end.After(to) {
+			end = to
+		}
+		res = append(res, Period{Start: start, End: end})
+		start = next
+	}
+	return res
+}
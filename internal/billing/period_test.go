@@ -0,0 +1,309 @@
+package billing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{in: "2025-01-15", n: 1, want: "2025-02-15"},
+		{in: "2025-01-31", n: 1, want: "2025-02-28"},
+		{in: "2024-01-31", n: 1, want: "2024-02-29"},
+		{in: "2025-01-31", n: 2, want: "2025-03-31"},
+		{in: "2025-03-31", n: 1, want: "2025-04-30"},
+		{in: "2025-12-31", n: 2, want: "2026-02-28"},
+		{in: "2024-02-29", n: 12, want: "2025-02-28"},
+		{in: "2024-02-29", n: 48, want: "2028-02-29"},
+		{in: "2025-03-31", n: -1, want: "2025-02-28"},
+		{in: "2025-05-31", n: 0, want: "2025-05-31"},
+	}
+	for _, tt := range tests {
+		if got := AddMonths(day(tt.in), tt.n); !got.Equal(day(tt.want)) {
+			t.Errorf("AddMonths(%s, %d) = %s, want %s", tt.in, tt.n, got.Format(time.DateOnly), tt.want)
+		}
+	}
+}
+
+func TestChargeDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		period   string
+		interval int
+		n        int
+		want     string
+	}{
+		{name: "monthly month end does not drift", start: "2025-01-31", period: model.BillingPeriodMonthly, n: 2, want: "2025-03-31"},
+		{name: "monthly clamps to february", start: "2025-01-31", period: model.BillingPeriodMonthly, n: 1, want: "2025-02-28"},
+		{name: "monthly leap february", start: "2024-01-30", period: model.BillingPeriodMonthly, n: 1, want: "2024-02-29"},
+		{name: "monthly default interval", start: "2025-01-10", period: model.BillingPeriodMonthly, interval: 0, n: 3, want: "2025-04-10"},
+		{name: "every two months", start: "2025-01-10", period: model.BillingPeriodMonthly, interval: 2, n: 3, want: "2025-07-10"},
+		{name: "weekly", start: "2025-01-01", period: model.BillingPeriodWeekly, interval: 1, n: 5, want: "2025-02-05"},
+		{name: "biweekly", start: "2025-01-01", period: model.BillingPeriodWeekly, interval: 2, n: 2, want: "2025-01-29"},
+		{name: "quarterly month end", start: "2024-11-30", period: model.BillingPeriodQuarterly, interval: 1, n: 1, want: "2025-02-28"},
+		{name: "quarterly", start: "2025-01-31", period: model.BillingPeriodQuarterly, interval: 1, n: 2, want: "2025-07-31"},
+		{name: "yearly leap day", start: "2024-02-29", period: model.BillingPeriodYearly, interval: 1, n: 1, want: "2025-02-28"},
+		{name: "yearly leap day next leap year", start: "2024-02-29", period: model.BillingPeriodYearly, interval: 1, n: 4, want: "2028-02-29"},
+		{name: "custom days across leap day", start: "2024-02-20", period: model.BillingPeriodCustom, interval: 10, n: 1, want: "2024-03-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &model.Subscription{StartDate: day(tt.start), BillingPeriod: tt.period, BillingInterval: tt.interval}
+			if got := ChargeDate(sub, tt.n); !got.Equal(day(tt.want)) {
+				t.Errorf("ChargeDate(%d) = %s, want %s", tt.n, got.Format(time.DateOnly), tt.want)
+			}
+		})
+	}
+}
+
+func TestChargeDateStartsAfterTrial(t *testing.T) {
+	trialEnd := day("2025-01-31")
+	sub := &model.Subscription{
+		StartDate:       day("2025-01-17"),
+		TrialEndDate:    &trialEnd,
+		BillingPeriod:   model.BillingPeriodMonthly,
+		BillingInterval: 1,
+	}
+	want := []string{"2025-01-31", "2025-02-28", "2025-03-31"}
+	for n, w := range want {
+		if got := ChargeDate(sub, n); !got.Equal(day(w)) {
+			t.Errorf("ChargeDate(%d) = %s, want %s", n, got.Format(time.DateOnly), w)
+		}
+	}
+}
+
+func TestPeriodIndexMatchesChargeDates(t *testing.T) {
+	periods := []string{
+		model.BillingPeriodWeekly, model.BillingPeriodMonthly, model.BillingPeriodQuarterly,
+		model.BillingPeriodYearly, model.BillingPeriodCustom,
+	}
+	starts := []string{"2024-01-31", "2024-02-29", "2025-08-30", "2025-12-31"}
+	for _, period := range periods {
+		for _, start := range starts {
+			for _, interval := range []int{1, 3} {
+				sub := &model.Subscription{StartDate: day(start), BillingPeriod: period, BillingInterval: interval}
+				for n := 0; n < 60; n++ {
+					date := ChargeDate(sub, n)
+					if got := periodIndex(sub, date); got != n {
+						t.Fatalf("%s/%d from %s: periodIndex(ChargeDate(%d)) = %d", period, interval, start, n, got)
+					}
+					if got := periodIndex(sub, date.Add(-time.Nanosecond)); n > 0 && got != n-1 {
+						t.Fatalf("%s/%d from %s: periodIndex just before ChargeDate(%d) = %d", period, interval, start, n, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	end := day("2025-04-15")
+	trialEnd := day("2025-01-31")
+	tests := []struct {
+		name     string
+		sub      model.Subscription
+		from, to string
+		want     []Period
+	}{
+		{
+			name: "month end anchor",
+			sub:  model.Subscription{StartDate: day("2025-01-31"), BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: "2025-01-01", to: "2025-04-01",
+			want: []Period{
+				{Start: day("2025-01-31"), End: day("2025-02-28")},
+				{Start: day("2025-02-28"), End: day("2025-03-31")},
+				{Start: day("2025-03-31"), End: day("2025-04-30")},
+			},
+		},
+		{
+			name: "skips periods before from",
+			sub:  model.Subscription{StartDate: day("2020-01-31"), BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: "2024-02-15", to: "2024-03-31",
+			want: []Period{
+				{Start: day("2024-01-31"), End: day("2024-02-29")},
+				{Start: day("2024-02-29"), End: day("2024-03-31")},
+				{Start: day("2024-03-31"), End: day("2024-04-30")},
+			},
+		},
+		{
+			name: "stops at end date",
+			sub:  model.Subscription{StartDate: day("2025-01-01"), EndDate: &end, BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: "2025-01-01", to: "2025-12-31",
+			want: []Period{
+				{Start: day("2025-01-01"), End: day("2025-02-01")},
+				{Start: day("2025-02-01"), End: day("2025-03-01")},
+				{Start: day("2025-03-01"), End: day("2025-04-01")},
+				{Start: day("2025-04-01"), End: day("2025-05-01")},
+			},
+		},
+		{
+			name: "trial comes first",
+			sub:  model.Subscription{StartDate: day("2025-01-17"), TrialEndDate: &trialEnd, BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: "2025-01-01", to: "2025-02-28",
+			want: []Period{
+				{Start: day("2025-01-17"), End: day("2025-01-31"), Trial: true},
+				{Start: day("2025-01-31"), End: day("2025-02-28")},
+				{Start: day("2025-02-28"), End: day("2025-03-31")},
+			},
+		},
+		{
+			name: "trial before from is skipped",
+			sub:  model.Subscription{StartDate: day("2025-01-17"), TrialEndDate: &trialEnd, BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: "2025-02-01", to: "2025-02-27",
+			want: []Period{
+				{Start: day("2025-01-31"), End: day("2025-02-28")},
+			},
+		},
+		{
+			name: "starts after to",
+			sub:  model.Subscription{StartDate: day("2026-01-01"), BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: "2025-01-01", to: "2025-12-31",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Periods(&tt.sub, day(tt.from), day(tt.to))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Periods returned %d periods, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) || got[i].Trial != tt.want[i].Trial {
+					t.Errorf("period %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPeriodsFarWindowIsCheap(t *testing.T) {
+	far := day("9999-01-01")
+	sub := &model.Subscription{StartDate: day("2025-01-01"), EndDate: &far, BillingPeriod: model.BillingPeriodCustom, BillingInterval: 1}
+	if got := Periods(sub, day("9998-12-22"), far); len(got) != 10 {
+		t.Fatalf("Periods returned %d periods, want 10", len(got))
+	}
+	if got, want := PeriodEnd(sub, day("9998-06-01")), day("9998-06-02"); !got.Equal(want) {
+		t.Errorf("PeriodEnd = %s, want %s", got, want)
+	}
+}
+
+func TestPeriodEnd(t *testing.T) {
+	trialEnd := day("2025-02-10")
+	tests := []struct {
+		name string
+		sub  model.Subscription
+		at   time.Time
+		want string
+	}{
+		{
+			name: "inside month",
+			sub:  model.Subscription{StartDate: day("2025-01-31"), BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			at:   day("2025-03-05"),
+			want: "2025-03-31",
+		},
+		{
+			name: "on a billing date",
+			sub:  model.Subscription{StartDate: day("2025-01-31"), BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			at:   day("2025-02-28"),
+			want: "2025-03-31",
+		},
+		{
+			name: "during trial",
+			sub:  model.Subscription{StartDate: day("2025-01-10"), TrialEndDate: &trialEnd, BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			at:   day("2025-01-20"),
+			want: "2025-02-10",
+		},
+		{
+			name: "weekly",
+			sub:  model.Subscription{StartDate: day("2025-01-01"), BillingPeriod: model.BillingPeriodWeekly, BillingInterval: 1},
+			at:   day("2025-01-09"),
+			want: "2025-01-15",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PeriodEnd(&tt.sub, tt.at); !got.Equal(day(tt.want)) {
+				t.Errorf("PeriodEnd = %s, want %s", got.Format(time.DateOnly), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextChargeDate(t *testing.T) {
+	end := day("2025-03-15")
+	pausedAt := day("2025-02-15")
+	resumeAt := day("2025-04-10")
+	tests := []struct {
+		name   string
+		sub    model.Subscription
+		from   time.Time
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "next month end",
+			sub:    model.Subscription{StartDate: day("2025-01-31"), BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from:   day("2025-02-28"),
+			want:   "2025-03-31",
+			wantOK: true,
+		},
+		{
+			name:   "before start",
+			sub:    model.Subscription{StartDate: day("2025-01-31"), BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from:   day("2024-12-01"),
+			want:   "2025-01-31",
+			wantOK: true,
+		},
+		{
+			name: "ends before next date",
+			sub:  model.Subscription{StartDate: day("2025-01-01"), EndDate: &end, BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: day("2025-03-02"),
+		},
+		{
+			name:   "skips dates during a pause",
+			sub:    model.Subscription{StartDate: day("2025-01-01"), PausedAt: &pausedAt, ResumeAt: &resumeAt, BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from:   day("2025-02-20"),
+			want:   "2025-05-01",
+			wantOK: true,
+		},
+		{
+			name: "paused indefinitely",
+			sub:  model.Subscription{StartDate: day("2025-01-01"), PausedAt: &pausedAt, BillingPeriod: model.BillingPeriodMonthly, BillingInterval: 1},
+			from: day("2025-02-20"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NextChargeDate(&tt.sub, tt.from)
+			if ok != tt.wantOK {
+				t.Fatalf("NextChargeDate ok = %t, want %t", ok, tt.wantOK)
+			}
+			if ok && !got.Equal(day(tt.want)) {
+				t.Errorf("NextChargeDate = %s, want %s", got.Format(time.DateOnly), tt.want)
+			}
+		})
+	}
+}
+
+func TestMonths(t *testing.T) {
+	got := Months(day("2024-01-15"), day("2024-03-10"))
+	want := []Period{
+		{Start: day("2024-01-15"), End: day("2024-02-01").Add(-time.Nanosecond)},
+		{Start: day("2024-02-01"), End: day("2024-03-01").Add(-time.Nanosecond)},
+		{Start: day("2024-03-01"), End: day("2024-03-10")},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Months returned %d buckets, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("bucket %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package billing
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type rate struct {
+	date  time.Time
+	value *big.Rat
+}
+
+// RateTable converts amounts between currencies using the exchange rate in
+// effect on a given date. Missing pairs are resolved through the inverse
+// rate and, failing that, through model.DefaultCurrency.
+type RateTable struct {
+	pairs map[[2]string][]rate
+}
+
+func NewRateTable(rates []model.ExchangeRate) (*RateTable, error) {
+	t := &RateTable{pairs: map[[2]string][]rate{}}
+	for _, r := range rates {
+		value, ok := new(big.Rat).SetString(r.Rate)
+		if !ok || value.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %s->%s on %s: %q",
+				r.FromCurrency, r.ToCurrency, r.EffectiveDate.Format(time.DateOnly), r.Rate)
+		}
+		key := [2]string{r.FromCurrency, r.ToCurrency}
+		t.pairs[key] = append(t.pairs[key], rate{date: r.EffectiveDate, value: value})
+	}
+	for _, list := range t.pairs {
+		sort.Slice(list, func(i, j int) bool { return list[i].date.Before(list[j].date) })
+	}
+	return t, nil
+}
+
+func (t *RateTable) Convert(amount model.Amount, from, to string, at time.Time) (model.Amount, error) {
+	if from == to || amount == 0 {
+		return amount, nil
+	}
+	r, ok := t.lookup(from, to, at)
+	if !ok && from != model.DefaultCurrency && to != model.DefaultCurrency {
+		viaFrom, okFrom := t.lookup(from, model.DefaultCurrency, at)
+		viaTo, okTo := t.lookup(model.DefaultCurrency, to, at)
+		if okFrom && okTo {
+			r, ok = new(big.Rat).Mul(viaFrom, viaTo), true
+		}
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s->%s on %s", model.ErrRateNotFound, from, to, at.Format(time.DateOnly))
+	}
+	return roundRat(new(big.Rat).Mul(big.NewRat(int64(amount), 1), r)), nil
+}
+
+func (t *RateTable) lookup(from, to string, at time.Time) (*big.Rat, bool) {
+	if r, ok := latest(t.pairs[[2]string{from, to}], at); ok {
+		return r, true
+	}
+	if r, ok := latest(t.pairs[[2]string{to, from}], at); ok {
+		return new(big.Rat).Inv(r), true
+	}
+	return nil, false
+}
+
+func latest(list []rate, at time.Time) (*big.Rat, bool) {
+	i := sort.Search(len(list), func(i int) bool { return list[i].date.After(at) })
+	if i == 0 {
+		return nil, false
+	}
+	return list[i-1].value, true
+}
+
+// roundRat rounds half away from zero to the nearest minor unit.
+func roundRat(r *big.Rat) model.Amount {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+	half := new(big.Int).Quo(den, big.NewInt(2))
+	if num.Sign() < 0 {
+		num.Sub(num, half)
+	} else {
+		num.Add(num, half)
+	}
+	return model.Amount(num.Quo(num, den).Int64())
+}
@@ -0,0 +1,101 @@
+package billing
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestRateTableConvert(t *testing.T) {
+	table, err := NewRateTable([]model.ExchangeRate{
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: "90", EffectiveDate: day("2025-01-01")},
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: "100.5", EffectiveDate: day("2025-03-01")},
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: "95", EffectiveDate: day("2025-02-01")},
+		{FromCurrency: "RUB", ToCurrency: "EUR", Rate: "0.01", EffectiveDate: day("2025-01-01")},
+	})
+	if err != nil {
+		t.Fatalf("NewRateTable: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		amount   model.Amount
+		from, to string
+		at       time.Time
+		want     model.Amount
+		wantErr  bool
+	}{
+		{name: "same currency", amount: 12345, from: "USD", to: "USD", at: day("2020-01-01"), want: 12345},
+		{name: "zero amount", amount: 0, from: "USD", to: "EUR", at: day("2020-01-01"), want: 0},
+		{name: "direct rate", amount: 1000, from: "USD", to: "RUB", at: day("2025-01-15"), want: 90000},
+		{name: "rate on its effective date", amount: 1000, from: "USD", to: "RUB", at: day("2025-02-01"), want: 95000},
+		{name: "latest rate", amount: 199, from: "USD", to: "RUB", at: day("2026-01-01"), want: 20000},
+		{name: "inverse rate", amount: 9000, from: "RUB", to: "USD", at: day("2025-01-15"), want: 100},
+		{name: "inverse rate rounds", amount: 100, from: "RUB", to: "USD", at: day("2025-01-15"), want: 1},
+		{name: "cross rate through RUB", amount: 1000, from: "USD", to: "EUR", at: day("2025-01-15"), want: 900},
+		{name: "cross rate inverse", amount: 900, from: "EUR", to: "USD", at: day("2025-01-15"), want: 1000},
+		{name: "negative amount", amount: -199, from: "USD", to: "RUB", at: day("2026-01-01"), want: -20000},
+		{name: "before first rate", amount: 1000, from: "USD", to: "RUB", at: day("2024-12-31"), wantErr: true},
+		{name: "unknown pair", amount: 1000, from: "EUR", to: "GBP", at: day("2025-01-15"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.Convert(tt.amount, tt.from, tt.to, tt.at)
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrRateNotFound) {
+					t.Fatalf("Convert error = %v, want ErrRateNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert(%d %s->%s) = %d, want %d", tt.amount, tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRateTableRejectsInvalidRates(t *testing.T) {
+	for _, r := range []string{"", "abc", "0", "-1.5"} {
+		_, err := NewRateTable([]model.ExchangeRate{
+			{FromCurrency: "USD", ToCurrency: "RUB", Rate: r, EffectiveDate: day("2025-01-01")},
+		})
+		if err == nil {
+			t.Errorf("NewRateTable accepted rate %q", r)
+		}
+	}
+}
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		want     model.Amount
+	}{
+		{num: 5, den: 2, want: 3},
+		{num: 7, den: 3, want: 2},
+		{num: 8, den: 3, want: 3},
+		{num: 149, den: 100, want: 1},
+		{num: 150, den: 100, want: 2},
+		{num: -5, den: 2, want: -3},
+		{num: -149, den: 100, want: -1},
+		{num: 10, den: 1, want: 10},
+	}
+	for _, tt := range tests {
+		if got := roundRat(big.NewRat(tt.num, tt.den)); got != tt.want {
+			t.Errorf("roundRat(%d/%d) = %d, want %d", tt.num, tt.den, got, tt.want)
+		}
+	}
+}
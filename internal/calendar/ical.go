@@ -0,0 +1,175 @@
+// Package calendar renders subscriptions as an iCalendar (RFC 5545) feed: a
+// recurring all-day event on every billing date and an event on the end date.
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/winnamu6/go-subscription-service/internal/billing"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	prodID    = "-//go-subscription-service//Subscriptions//EN"
+	uidDomain = "go-subscription-service"
+	dateForm  = "20060102"
+	stampForm = "20060102T150405Z"
+
+	// maxLineOctets is the longest content line allowed before folding.
+	maxLineOctets = 75
+)
+
+// Feed renders the calendar with the billing and end date events of subs.
+func Feed(name string, subs []model.SubscriptionResponse) []byte {
+	var w writer
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + prodID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + escapeText(name))
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+	w.line("X-PUBLISHED-TTL:PT12H")
+
+	for i := range subs {
+		sub := &subs[i]
+		if rule, ok := billingRule(sub); ok {
+			w.event(
+				fmt.Sprintf("subscription-%d-billing@%s", sub.ID, uidDomain),
+				sub.UpdatedAt,
+				billing.BillingStart(toSubscription(sub)),
+				fmt.Sprintf("%s: %s %s", sub.ServiceName, sub.Price, sub.Currency),
+				fmt.Sprintf("Subscription #%d renews (%s)", sub.ID, periodText(sub)),
+				rule,
+			)
+		}
+		if sub.EndDate != nil {
+			w.event(
+				fmt.Sprintf("subscription-%d-end@%s", sub.ID, uidDomain),
+				sub.UpdatedAt,
+				*sub.EndDate,
+				fmt.Sprintf("%s ends", sub.ServiceName),
+				fmt.Sprintf("Subscription #%d ends", sub.ID),
+				"",
+			)
+		}
+	}
+
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// billingRule returns the RRULE reproducing billing.ChargeDate. Month based
+// periods starting after the 28th use BYMONTHDAY=d,-1;BYSETPOS=1, which picks
+// the day or the last day of shorter months, just like billing.AddMonths.
+// ok is false when the subscription ends before its first regular charge.
+func billingRule(sub *model.SubscriptionResponse) (string, bool) {
+	start := billing.BillingStart(toSubscription(sub))
+	interval := sub.BillingInterval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var rule string
+	monthBased := true
+	switch sub.BillingPeriod {
+	case model.BillingPeriodWeekly:
+		rule, monthBased = fmt.Sprintf("FREQ=WEEKLY;INTERVAL=%d", interval), false
+	case model.BillingPeriodCustom:
+		rule, monthBased = fmt.Sprintf("FREQ=DAILY;INTERVAL=%d", interval), false
+	case model.BillingPeriodQuarterly:
+		rule = fmt.Sprintf("FREQ=MONTHLY;INTERVAL=%d", 3*interval)
+	case model.BillingPeriodYearly:
+		rule = fmt.Sprintf("FREQ=YEARLY;INTERVAL=%d", interval)
+	default:
+		rule = fmt.Sprintf("FREQ=MONTHLY;INTERVAL=%d", interval)
+	}
+
+	if day := start.Day(); monthBased && day > 28 {
+		if sub.BillingPeriod == model.BillingPeriodYearly {
+			rule += fmt.Sprintf(";BYMONTH=%d", start.Month())
+		}
+		rule += fmt.Sprintf(";BYMONTHDAY=%d,-1;BYSETPOS=1", day)
+	}
+
+	if sub.EndDate != nil {
+		// only the last period before the end date is needed for UNTIL
+		periods := billing.Periods(toSubscription(sub), sub.EndDate.Add(-time.Nanosecond), *sub.EndDate)
+		if len(periods) == 0 || periods[len(periods)-1].Trial {
+			return "", false
+		}
+		rule += ";UNTIL=" + periods[len(periods)-1].Start.Format(dateForm)
+	}
+	return rule, true
+}
+
+// toSubscription returns the fields of sub the billing schedule depends on.
+func toSubscription(sub *model.SubscriptionResponse) *model.Subscription {
+	return &model.Subscription{
+		StartDate:       sub.StartDate,
+		EndDate:         sub.EndDate,
+		BillingPeriod:   sub.BillingPeriod,
+		BillingInterval: sub.BillingInterval,
+		TrialEndDate:    sub.TrialEndDate,
+		TrialPrice:      sub.TrialPrice,
+	}
+}
+
+func periodText(sub *model.SubscriptionResponse) string {
+	if sub.BillingPeriod == model.BillingPeriodCustom {
+		return fmt.Sprintf("every %d days", sub.BillingInterval)
+	}
+	if sub.BillingInterval > 1 {
+		return fmt.Sprintf("%s, every %d periods", sub.BillingPeriod, sub.BillingInterval)
+	}
+	return sub.BillingPeriod
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+func (w *writer) event(uid string, stamp, date time.Time, summary, description, rule string) {
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + uid)
+	w.line("DTSTAMP:" + stamp.UTC().Format(stampForm))
+	w.line("DTSTART;VALUE=DATE:" + date.Format(dateForm))
+	if rule != "" {
+		w.line("RRULE:" + rule)
+	}
+	w.line("SUMMARY:" + escapeText(summary))
+	w.line("DESCRIPTION:" + escapeText(description))
+	w.line("TRANSP:TRANSPARENT")
+	w.line("END:VEVENT")
+}
+
+// line writes a content line, folding it into CRLF-space continuations of at
+// most 75 octets without splitting UTF-8 sequences.
+func (w *writer) line(s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// the leading space of a continuation counts towards its length
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
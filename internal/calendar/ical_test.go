@@ -0,0 +1,181 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func sub(start, period string, interval int) model.SubscriptionResponse {
+	return model.SubscriptionResponse{
+		ID:              7,
+		ServiceName:     "Yandex Plus",
+		Price:           39900,
+		Currency:        model.CurrencyRUB,
+		StartDate:       day(start),
+		BillingPeriod:   period,
+		BillingInterval: interval,
+		UpdatedAt:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestBillingRule(t *testing.T) {
+	ended := sub("2025-01-15", model.BillingPeriodMonthly, 1)
+	ended.EndDate = ptr(day("2025-04-10"))
+
+	endsOnChargeDate := sub("2025-01-15", model.BillingPeriodMonthly, 1)
+	endsOnChargeDate.EndDate = ptr(day("2025-04-15"))
+
+	trial := sub("2025-01-01", model.BillingPeriodMonthly, 1)
+	trial.TrialEndDate = ptr(day("2025-01-31"))
+
+	endsInTrial := trial
+	endsInTrial.EndDate = ptr(day("2025-01-20"))
+
+	tests := []struct {
+		name   string
+		sub    model.SubscriptionResponse
+		want   string
+		wantOK bool
+	}{
+		{name: "monthly", sub: sub("2025-01-15", model.BillingPeriodMonthly, 1), want: "FREQ=MONTHLY;INTERVAL=1", wantOK: true},
+		{name: "monthly on the 28th", sub: sub("2025-01-28", model.BillingPeriodMonthly, 1), want: "FREQ=MONTHLY;INTERVAL=1", wantOK: true},
+		{name: "monthly month end", sub: sub("2025-01-31", model.BillingPeriodMonthly, 1), want: "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=31,-1;BYSETPOS=1", wantOK: true},
+		{name: "monthly zero interval", sub: sub("2025-01-15", model.BillingPeriodMonthly, 0), want: "FREQ=MONTHLY;INTERVAL=1", wantOK: true},
+		{name: "quarterly", sub: sub("2025-01-30", model.BillingPeriodQuarterly, 2), want: "FREQ=MONTHLY;INTERVAL=6;BYMONTHDAY=30,-1;BYSETPOS=1", wantOK: true},
+		{name: "yearly leap day", sub: sub("2024-02-29", model.BillingPeriodYearly, 1), want: "FREQ=YEARLY;INTERVAL=1;BYMONTH=2;BYMONTHDAY=29,-1;BYSETPOS=1", wantOK: true},
+		{name: "weekly", sub: sub("2025-01-31", model.BillingPeriodWeekly, 2), want: "FREQ=WEEKLY;INTERVAL=2", wantOK: true},
+		{name: "custom", sub: sub("2025-01-31", model.BillingPeriodCustom, 10), want: "FREQ=DAILY;INTERVAL=10", wantOK: true},
+		{name: "starts at trial end", sub: trial, want: "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=31,-1;BYSETPOS=1", wantOK: true},
+		{name: "until last charge before end", sub: ended, want: "FREQ=MONTHLY;INTERVAL=1;UNTIL=20250315", wantOK: true},
+		{name: "end date is not charged", sub: endsOnChargeDate, want: "FREQ=MONTHLY;INTERVAL=1;UNTIL=20250315", wantOK: true},
+		{name: "ends during trial", sub: endsInTrial, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := billingRule(&tt.sub)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("billingRule() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: "a,b;c", want: `a\,b\;c`},
+		{in: `back\slash`, want: `back\\slash`},
+		{in: "two\nlines", want: `two\nlines`},
+		{in: "two\r\nlines", want: `two\nlines`},
+		{in: `\n`, want: `\\n`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "short", in: "SUMMARY:x", want: "SUMMARY:x\r\n"},
+		{name: "exactly 75 octets", in: strings.Repeat("a", 75), want: strings.Repeat("a", 75) + "\r\n"},
+		{
+			name: "continuations hold 74 octets",
+			in:   strings.Repeat("a", 75+74+1),
+			want: strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n",
+		},
+		{
+			// "я" is two octets: the 38th would end at octet 76
+			name: "does not split UTF-8",
+			in:   strings.Repeat("я", 40),
+			want: strings.Repeat("я", 37) + "\r\n " + strings.Repeat("я", 3) + "\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w writer
+			w.line(tt.in)
+			if got := w.buf.String(); got != tt.want {
+				t.Errorf("line() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeed(t *testing.T) {
+	active := sub("2025-01-31", model.BillingPeriodMonthly, 1)
+
+	ended := sub("2025-01-15", model.BillingPeriodMonthly, 1)
+	ended.ID = 8
+	ended.EndDate = ptr(day("2025-04-10"))
+
+	endsInTrial := sub("2025-01-01", model.BillingPeriodMonthly, 1)
+	endsInTrial.ID = 9
+	endsInTrial.TrialEndDate = ptr(day("2025-01-31"))
+	endsInTrial.EndDate = ptr(day("2025-01-20"))
+
+	got := string(Feed("Subscriptions", []model.SubscriptionResponse{active, ended, endsInTrial}))
+
+	for _, line := range strings.SplitAfter(got, "\r\n") {
+		if strings.Contains(strings.TrimSuffix(line, "\r\n"), "\n") {
+			t.Errorf("line %q is not terminated with CRLF", line)
+		}
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "header", want: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:" + prodID + "\r\n"},
+		{name: "billing event", want: "BEGIN:VEVENT\r\n" +
+			"UID:subscription-7-billing@go-subscription-service\r\n" +
+			"DTSTAMP:20250102T030405Z\r\n" +
+			"DTSTART;VALUE=DATE:20250131\r\n" +
+			"RRULE:FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=31,-1;BYSETPOS=1\r\n" +
+			"SUMMARY:Yandex Plus: 399.00 RUB\r\n" +
+			"DESCRIPTION:Subscription #7 renews (monthly)\r\n" +
+			"TRANSP:TRANSPARENT\r\n" +
+			"END:VEVENT\r\n"},
+		{name: "billing event with until", want: "RRULE:FREQ=MONTHLY;INTERVAL=1;UNTIL=20250315\r\n"},
+		{name: "end event", want: "UID:subscription-8-end@go-subscription-service\r\n" +
+			"DTSTAMP:20250102T030405Z\r\n" +
+			"DTSTART;VALUE=DATE:20250410\r\n" +
+			"SUMMARY:Yandex Plus ends\r\n"},
+		{name: "end event of a subscription without charges", want: "UID:subscription-9-end@go-subscription-service\r\n"},
+		{name: "footer", want: "END:VEVENT\r\nEND:VCALENDAR\r\n"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s: feed does not contain %q\n%s", tt.name, tt.want, got)
+		}
+	}
+	if strings.Contains(got, "subscription-9-billing") {
+		t.Errorf("feed has a billing event for a subscription that ends during its trial")
+	}
+	if !strings.HasSuffix(got, "END:VCALENDAR\r\n") {
+		t.Errorf("feed does not end with END:VCALENDAR")
+	}
+}
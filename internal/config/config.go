@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
@@ -14,6 +16,50 @@ type Config struct {
 	DBUser  string
 	DBPass  string
 	DBName  string
+
+	AdminToken string
+
+	RenewalInterval time.Duration
+
+	// DeletedRetention is how long soft-deleted subscriptions are kept before
+	// they can be purged.
+	DeletedRetention time.Duration
+
+	// IdempotencyTTL is how long responses to requests with an
+	// Idempotency-Key are kept for replay.
+	IdempotencyTTL time.Duration
+
+	// WebhookInterval is how often the worker sends due webhook deliveries;
+	// WebhookTimeout bounds a single delivery attempt and WebhookMaxAttempts
+	// is the number of attempts before a delivery is marked as failed.
+	WebhookInterval    time.Duration
+	WebhookTimeout     time.Duration
+	WebhookMaxAttempts int
+
+	// OutboxInterval is how often the worker publishes pending outbox
+	// messages; published messages are kept for OutboxRetention and a
+	// message is parked as failed after OutboxMaxAttempts attempts. EventSink
+	// is "stdout" or a file that receives every event as a JSON line; it is
+	// disabled when empty.
+	OutboxInterval    time.Duration
+	OutboxRetention   time.Duration
+	OutboxMaxAttempts int
+	EventSink         string
+
+	// SMTP server used to send reminder emails. Credentials are optional.
+	SMTPHost string
+	SMTPPort string
+	SMTPUser string
+	SMTPPass string
+	SMTPFrom string
+
+	// ReminderInterval is how often the worker looks for reminders to send;
+	// ReminderDaysAhead is how many days before a billing or end date users
+	// are reminded unless they chose otherwise. ReminderTemplatesDir may hold
+	// <kind>.tmpl files overriding the built-in templates.
+	ReminderInterval     time.Duration
+	ReminderDaysAhead    int
+	ReminderTemplatesDir string
 }
 
 func Load() *Config {
@@ -32,9 +78,36 @@ func Load() *Config {
 		DBUser:  getEnv("DB_USER", "postgres"),
 		DBPass:  getEnv("DB_PASS", ""),
 		DBName:  getEnv("DB_NAME", "subscription"),
+
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+
+		RenewalInterval: getDuration("RENEWAL_INTERVAL", time.Hour),
+
+		DeletedRetention: getDuration("DELETED_RETENTION", 30*24*time.Hour),
+		IdempotencyTTL:   getDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+
+		WebhookInterval:    getDuration("WEBHOOK_INTERVAL", 10*time.Second),
+		WebhookTimeout:     getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookMaxAttempts: getInt("WEBHOOK_MAX_ATTEMPTS", 10),
+
+		OutboxInterval:    getDuration("OUTBOX_INTERVAL", 2*time.Second),
+		OutboxRetention:   getDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+		OutboxMaxAttempts: getInt("OUTBOX_MAX_ATTEMPTS", 10),
+		EventSink:         getEnv("EVENT_SINK", ""),
+
+		SMTPHost: getEnv("SMTP_HOST", "localhost"),
+		SMTPPort: getEnv("SMTP_PORT", "1025"),
+		SMTPUser: getEnv("SMTP_USER", ""),
+		SMTPPass: getEnv("SMTP_PASS", ""),
+		SMTPFrom: getEnv("SMTP_FROM", "Subscriptions <noreply@localhost>"),
+
+		ReminderInterval:     getDuration("REMINDER_INTERVAL", time.Hour),
+		ReminderDaysAhead:    getInt("REMINDER_DAYS_AHEAD", 3),
+		ReminderTemplatesDir: getEnv("REMINDER_TEMPLATES_DIR", ""),
 	}
 
-	log.Infof("Config loaded: %+v", cfg)
+	log.Infof("Config loaded: app_port=%s db=%s@%s:%s/%s admin_token_set=%t",
+		cfg.AppPort, cfg.DBUser, cfg.DBHost, cfg.DBPort, cfg.DBName, cfg.AdminToken != "")
 	return cfg
 }
 
@@ -44,3 +117,29 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Get().Warnf("Invalid duration in %s=%q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+func getInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		logger.Get().Warnf("Invalid number in %s=%q, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+var models = []any{
+	&model.Service{},
+	&model.Subscription{},
+	&model.ExchangeRate{},
+	&model.Charge{},
+	&model.SubscriptionPrice{},
+	&model.SubscriptionPause{},
+	&model.SubscriptionCancellation{},
+	&model.AuditEvent{},
+	&model.IdempotencyKey{},
+	&model.CalendarToken{},
+	&model.WebhookEndpoint{},
+	&model.WebhookDelivery{},
+	&model.OutboxMessage{},
+	&model.NotificationPreference{},
+	&model.SentReminder{},
+}
+
+// migration is a one-off data migration. Migrations run after AutoMigrate, in
+// order, each in its own transaction, and are recorded in schema_migrations so
+// that they are applied exactly once.
+type migration struct {
+	ID string
+	Up func(tx *gorm.DB) error
+}
+
+var migrations = []migration{
+	{ID: "0001_price_minor_units", Up: migratePriceToMinorUnits},
+	{ID: "0002_subscription_price_history", Up: backfillPriceHistory},
+	{ID: "0003_service_catalog", Up: linkServiceCatalog},
+}
+
+type schemaMigration struct {
+	ID        string `gorm:"primaryKey;type:varchar(255)"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// migrationLockNamespace is the first key of the session level advisory lock
+// held while migrating, so that the api and the worker starting together do
+// not migrate the schema concurrently.
+const migrationLockNamespace = 7000
+
+// Migrate brings the schema up to date. Concurrent callers wait for each
+// other on an advisory lock held on a dedicated connection.
+func Migrate(db *gorm.DB) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?, 0)", migrationLockNamespace).Error; err != nil {
+			return fmt.Errorf("lock migrations: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?, 0)", migrationLockNamespace)
+
+		return migrate(conn)
+	})
+}
+
+func migrate(db *gorm.DB) error {
+	log := logger.Get()
+
+	if err := db.AutoMigrate(append([]any{&schemaMigration{}}, models...)...); err != nil {
+		return fmt.Errorf("auto migrate: %w", err)
+	}
+
+	for _, m := range migrations {
+		var count int64
+		if err := db.Model(&schemaMigration{}).Where("id = ?", m.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		log.Infof("[Migrate] Applying migration %s", m.ID)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{ID: m.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", m.ID, err)
+		}
+		log.Infof("[Migrate] Migration %s applied", m.ID)
+	}
+
+	return nil
+}
+
+// migratePriceToMinorUnits converts the legacy integer rubles column "price"
+// into kopecks stored in "price_minor".
+func migratePriceToMinorUnits(tx *gorm.DB) error {
+	if !tx.Migrator().HasColumn("subscriptions", "price") {
+		return nil
+	}
+	if err := tx.Exec("UPDATE subscriptions SET price_minor = price::bigint * 100, currency = 'RUB'").Error; err != nil {
+		return err
+	}
+	return tx.Migrator().DropColumn("subscriptions", "price")
+}
+
+// backfillPriceHistory records the current price of every existing
+// subscription as effective from its start date.
+func backfillPriceHistory(tx *gorm.DB) error {
+	return tx.Exec(`
+		INSERT INTO subscription_prices (subscription_id, amount_minor, currency, effective_from, created_at)
+		SELECT s.id, s.price_minor, s.currency, s.start_date, NOW()
+		FROM subscriptions s
+		WHERE NOT EXISTS (SELECT 1 FROM subscription_prices p WHERE p.subscription_id = s.id)`).Error
+}
+
+// linkServiceCatalog seeds the service catalog from the service names of
+// existing subscriptions and links the subscriptions named exactly like an
+// entry. The other spellings are logged; POST /admin/services/match links
+// them under the canonical name.
+func linkServiceCatalog(tx *gorm.DB) error {
+	log := logger.Get()
+
+	seeded, err := seedServiceCatalog(tx)
+	if err != nil {
+		return err
+	}
+	linked, err := linkServiceNames(tx)
+	if err != nil {
+		return err
+	}
+	unlinked, err := unlinkedServiceNames(tx)
+	if err != nil {
+		return err
+	}
+	for _, u := range unlinked {
+		log.Warnf("[Migrate] Service name %q left to POST /admin/services/match | subscriptions=%d", u.ServiceName, u.Subscriptions)
+	}
+	log.Infof("[Migrate] Service catalog seeded | services=%d linked=%d unlinked_names=%d", seeded, linked, len(unlinked))
+	return nil
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+// seedServiceCatalog adds a catalog entry for every service_name of the
+// subscriptions without a service_id that no entry matches yet. Names that
+// differ only in case, whitespace or "ё" share one entry named after their
+// most common spelling.
+func seedServiceCatalog(tx *gorm.DB) (int, error) {
+	var services []model.Service
+	if err := tx.Find(&services).Error; err != nil {
+		return 0, err
+	}
+	matcher := model.NewServiceMatcher(services)
+
+	var names []struct {
+		ServiceName string
+		Count       int64
+	}
+	err := tx.Unscoped().Model(&model.Subscription{}).
+		Select("service_name, COUNT(*) AS count").
+		Where("service_id IS NULL").
+		Group("service_name").
+		Order("service_name").
+		Scan(&names).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var keys []string
+	spellings := make(map[string]string)
+	counts := make(map[string]int64)
+	for _, n := range names {
+		if matcher.Match(n.ServiceName) != nil {
+			continue
+		}
+		key := model.NormalizeServiceName(n.ServiceName)
+		if _, ok := spellings[key]; !ok {
+			keys = append(keys, key)
+		}
+		if n.Count > counts[key] {
+			spellings[key], counts[key] = n.ServiceName, n.Count
+		}
+	}
+
+	for _, key := range keys {
+		svc := &model.Service{
+			Name:                   strings.TrimSpace(spellings[key]),
+			Aliases:                []string{},
+			DefaultCurrency:        model.DefaultCurrency,
+			DefaultBillingPeriod:   model.BillingPeriodMonthly,
+			DefaultBillingInterval: 1,
+		}
+		if err := tx.Create(svc).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(keys), nil
+}
+
+// linkServiceNames sets service_id on the subscriptions without one whose
+// service_name is exactly the name of a catalog entry. Like the price history
+// backfill it only fills in a new column, so it records no audit events;
+// other spellings are renamed by POST /admin/services/match, which does.
+func linkServiceNames(tx *gorm.DB) (int64, error) {
+	res := tx.Exec(`
+		UPDATE subscriptions s SET service_id = c.id
+		FROM services c
+		WHERE s.service_id IS NULL AND s.service_name = c.name`)
+	return res.RowsAffected, res.Error
+}
+
+// unlinkedServiceNames counts the subscriptions without a service_id by name.
+func unlinkedServiceNames(tx *gorm.DB) ([]model.UnmatchedServiceName, error) {
+	var names []model.UnmatchedServiceName
+	err := tx.Unscoped().Model(&model.Subscription{}).
+		Select("service_name, COUNT(*) AS subscriptions").
+		Where("service_id IS NULL").
+		Group("service_name").
+		Order("service_name").
+		Scan(&names).Error
+	return names, err
+}
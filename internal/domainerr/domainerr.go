@@ -0,0 +1,140 @@
+// Package domainerr defines typed errors shared by repositories and services.
+// Each error carries a Kind, which the HTTP layer maps to a status code, and a
+// stable machine-readable Code for clients.
+package domainerr
+
+import "errors"
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+	KindUnprocessable
+	KindUnsupportedMediaType
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindValidation:
+		return "validation"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindForbidden:
+		return "forbidden"
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindPreconditionFailed:
+		return "precondition_failed"
+	case KindUnprocessable:
+		return "unprocessable"
+	case KindUnsupportedMediaType:
+		return "unsupported_media_type"
+	default:
+		return "internal"
+	}
+}
+
+const CodeInternal = "internal_error"
+
+// FieldError describes why a single request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Error is a domain error. Message is safe to show to clients; the wrapped Err,
+// if any, is kept for logs only.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap attaches a kind and code to err.
+func Wrap(err error, kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// InvalidFields is a validation error listing the rejected fields.
+func InvalidFields(err error, fields []FieldError) *Error {
+	return &Error{
+		Kind:    KindValidation,
+		Code:    "validation_failed",
+		Message: "request validation failed",
+		Fields:  fields,
+		Err:     err,
+	}
+}
+
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+func Unprocessable(code, message string) *Error {
+	return New(KindUnprocessable, code, message)
+}
+
+func UnsupportedMediaType(code, message string) *Error {
+	return New(KindUnsupportedMediaType, code, message)
+}
+
+// As returns the first domain error in err's chain.
+func As(err error) (*Error, bool) {
+	var de *Error
+	if errors.As(err, &de) {
+		return de, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of the first domain error in err's chain, or
+// KindInternal if there is none.
+func KindOf(err error) Kind {
+	if de, ok := As(err); ok {
+		return de.Kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+// AllEvents subscribes a handler to every event type.
+const AllEvents = "*"
+
+type Handler func(ctx context.Context, msg *model.OutboxMessage) error
+
+// Bus is an in-process publisher that calls the handlers subscribed to the
+// type of each message, in the order they subscribed. Handlers run
+// synchronously within the relay transaction, so database writes made through
+// ctx are committed together with marking the message as published.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers h for eventType, or for every event with AllEvents.
+func (b *Bus) Subscribe(eventType string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], h)
+}
+
+func (b *Bus) Publish(ctx context.Context, msg *model.OutboxMessage) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[msg.EventType]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(ctx, msg); err != nil {
+			return fmt.Errorf("bus handler for %s: %w", msg.EventType, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+// Package events publishes outbox messages. The outbox relay hands every
+// message to a Publisher at least once and in order per ordering key, so
+// consumers must tolerate duplicates; the event ID identifies them.
+package events
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, msg *model.OutboxMessage) error
+}
+
+// Publishers publishes to each publisher in turn and stops at the first
+// error. The relay then retries the message with all of them.
+type Publishers []Publisher
+
+func (ps Publishers) Publish(ctx context.Context, msg *model.OutboxMessage) error {
+	for _, p := range ps {
+		if err := p.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
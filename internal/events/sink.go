@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+// SinkStdout selects standard output in OpenSink.
+const SinkStdout = "stdout"
+
+// Sink writes each event as one line of JSON, the payload of the message.
+type Sink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+func NewSink(w io.Writer) *Sink {
+	return &Sink{w: w}
+}
+
+// OpenSink returns a sink writing to standard output for SinkStdout, or
+// appending to the file at target otherwise.
+func OpenSink(target string) (*Sink, error) {
+	if target == SinkStdout {
+		return NewSink(os.Stdout), nil
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Sink{w: f, closer: f}, nil
+}
+
+func (s *Sink) Publish(_ context.Context, msg *model.OutboxMessage) error {
+	line := make([]byte, 0, len(msg.Payload)+1)
+	line = append(append(line, msg.Payload...), '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.w.Write(line)
+	return err
+}
+
+func (s *Sink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
@@ -0,0 +1,45 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []Column
+	locale  Locale
+	record  []string
+}
+
+func newCSVWriter(w io.Writer, columns []Column, locale Locale) (*csvWriter, error) {
+	cw := &csvWriter{
+		w:       csv.NewWriter(w),
+		columns: columns,
+		locale:  locale,
+		record:  make([]string, len(columns)),
+	}
+	cw.w.Comma = locale.CSVDelimiter
+
+	for i, c := range columns {
+		cw.record[i] = c.Name
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(sub *model.SubscriptionResponse) error {
+	for i, c := range cw.columns {
+		cw.record[i] = cw.locale.format(c.get(sub))
+	}
+	return cw.w.Write(cw.record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,166 @@
+// Package export writes subscriptions as CSV, XLSX or JSON Lines one row at a
+// time, so that exports of any size can be streamed to the client.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatXLSX   = "xlsx"
+	FormatNDJSON = "ndjson"
+)
+
+var (
+	ErrUnsupportedFormat = domainerr.Validation("unsupported_export_format", "export format must be csv, xlsx or ndjson")
+	ErrUnknownColumn     = domainerr.Validation("unknown_export_column", "unknown export column")
+	ErrUnknownLocale     = domainerr.Validation("unknown_export_locale", "unknown export locale")
+)
+
+// Writer writes subscriptions in one of the export formats. Close must be
+// called to complete the file.
+type Writer interface {
+	Write(sub *model.SubscriptionResponse) error
+	Close() error
+}
+
+// NewWriter starts a file in the given format and writes its header.
+func NewWriter(w io.Writer, format string, columns []Column, locale Locale) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns, locale)
+	case FormatXLSX:
+		return newXLSXWriter(w, columns, locale)
+	case FormatNDJSON:
+		return newNDJSONWriter(w, columns), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindInt
+	kindMoney
+	kindDate
+	kindDateTime
+)
+
+type value struct {
+	kind valueKind
+	null bool
+	str  string
+	num  int64 // integer value or amount in minor units
+	time time.Time
+}
+
+func stringValue(s string) value      { return value{kind: kindString, str: s} }
+func intValue(n int64) value          { return value{kind: kindInt, num: n} }
+func moneyValue(a model.Amount) value { return value{kind: kindMoney, num: int64(a)} }
+func dateValue(t time.Time) value     { return value{kind: kindDate, time: t.UTC()} }
+func dateTimeValue(t time.Time) value { return value{kind: kindDateTime, time: t.UTC()} }
+
+func optional(t *time.Time, fn func(time.Time) value, kind valueKind) value {
+	if t == nil {
+		return value{kind: kind, null: true}
+	}
+	return fn(*t)
+}
+
+// Column is an exported field of a subscription.
+type Column struct {
+	Name string
+	get  func(sub *model.SubscriptionResponse) value
+}
+
+var columns = []Column{
+	{"id", func(s *model.SubscriptionResponse) value { return intValue(int64(s.ID)) }},
+	{"service_name", func(s *model.SubscriptionResponse) value { return stringValue(s.ServiceName) }},
+	{"service_id", func(s *model.SubscriptionResponse) value {
+		if s.ServiceID == nil {
+			return value{kind: kindInt, null: true}
+		}
+		return intValue(int64(*s.ServiceID))
+	}},
+	{"price", func(s *model.SubscriptionResponse) value { return moneyValue(s.Price) }},
+	{"currency", func(s *model.SubscriptionResponse) value { return stringValue(s.Currency) }},
+	{"user_id", func(s *model.SubscriptionResponse) value { return stringValue(s.UserID.String()) }},
+	{"start_date", func(s *model.SubscriptionResponse) value { return dateValue(s.StartDate) }},
+	{"end_date", func(s *model.SubscriptionResponse) value { return optional(s.EndDate, dateValue, kindDate) }},
+	{"billing_period", func(s *model.SubscriptionResponse) value { return stringValue(s.BillingPeriod) }},
+	{"billing_interval", func(s *model.SubscriptionResponse) value { return intValue(int64(s.BillingInterval)) }},
+	{"trial_end_date", func(s *model.SubscriptionResponse) value { return optional(s.TrialEndDate, dateValue, kindDate) }},
+	{"trial_price", func(s *model.SubscriptionResponse) value { return moneyValue(s.TrialPrice) }},
+	{"version", func(s *model.SubscriptionResponse) value { return intValue(int64(s.Version)) }},
+	{"created_at", func(s *model.SubscriptionResponse) value { return dateTimeValue(s.CreatedAt) }},
+	{"updated_at", func(s *model.SubscriptionResponse) value { return dateTimeValue(s.UpdatedAt) }},
+	{"deleted_at", func(s *model.SubscriptionResponse) value { return optional(s.DeletedAt, dateTimeValue, kindDateTime) }},
+}
+
+// DefaultColumns are exported when no columns are requested.
+var DefaultColumns = []string{
+	"id", "service_name", "price", "currency", "user_id", "start_date", "end_date", "billing_period", "billing_interval",
+}
+
+// ColumnNames lists every column that can be exported.
+func ColumnNames() []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// ParseColumns resolves a comma-separated list of column names, keeping the
+// requested order. An empty list selects DefaultColumns.
+func ParseColumns(list string) ([]Column, error) {
+	names := DefaultColumns
+	if strings.TrimSpace(list) != "" {
+		names = strings.Split(list, ",")
+	}
+
+	selected := make([]Column, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		col, ok := lookupColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, expected one of %s", ErrUnknownColumn, name, strings.Join(ColumnNames(), ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %q is listed twice", ErrUnknownColumn, name)
+		}
+		seen[name] = true
+		selected = append(selected, col)
+	}
+	return selected, nil
+}
+
+func lookupColumn(name string) (Column, bool) {
+	for _, c := range columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+// Locale controls how dates and amounts are written to CSV and XLSX. JSON
+// Lines always use RFC 3339 dates and plain decimal amounts.
+type Locale struct {
+	Name           string
+	DateLayout     string
+	DateTimeLayout string
+	DecimalSep     string
+	GroupSep       string
+	// CSVDelimiter separates CSV fields; locales with a decimal comma use a
+	// semicolon, as spreadsheet applications expect.
+	CSVDelimiter rune
+	// XLSXDateFormat and XLSXDateTimeFormat are Excel number formats.
+	XLSXDateFormat     string
+	XLSXDateTimeFormat string
+}
+
+var locales = map[string]Locale{
+	"": {
+		DateLayout:         "2006-01-02",
+		DateTimeLayout:     "2006-01-02T15:04:05Z07:00",
+		DecimalSep:         ".",
+		CSVDelimiter:       ',',
+		XLSXDateFormat:     "yyyy-mm-dd",
+		XLSXDateTimeFormat: "yyyy-mm-dd hh:mm:ss",
+	},
+	"en": {
+		Name:               "en",
+		DateLayout:         "01/02/2006",
+		DateTimeLayout:     "01/02/2006 15:04",
+		DecimalSep:         ".",
+		GroupSep:           ",",
+		CSVDelimiter:       ',',
+		XLSXDateFormat:     "mm/dd/yyyy",
+		XLSXDateTimeFormat: "mm/dd/yyyy hh:mm",
+	},
+	"ru": {
+		Name:               "ru",
+		DateLayout:         "02.01.2006",
+		DateTimeLayout:     "02.01.2006 15:04",
+		DecimalSep:         ",",
+		GroupSep:           "\u00a0",
+		CSVDelimiter:       ';',
+		XLSXDateFormat:     "dd.mm.yyyy",
+		XLSXDateTimeFormat: "dd.mm.yyyy hh:mm",
+	},
+}
+
+// LookupLocale returns a locale by name; the empty name selects ISO dates and
+// plain decimal amounts.
+func LookupLocale(name string) (Locale, error) {
+	locale, ok := locales[name]
+	if !ok {
+		return Locale{}, fmt.Errorf("%w: %q", ErrUnknownLocale, name)
+	}
+	return locale, nil
+}
+
+// format renders v as text; null values are empty.
+func (l Locale) format(v value) string {
+	if v.null {
+		return ""
+	}
+	switch v.kind {
+	case kindInt:
+		return fmt.Sprint(v.num)
+	case kindMoney:
+		return l.formatMoney(model.Amount(v.num))
+	case kindDate:
+		return v.time.Format(l.DateLayout)
+	case kindDateTime:
+		return v.time.Format(l.DateTimeLayout)
+	default:
+		return v.str
+	}
+}
+
+func (l Locale) formatMoney(a model.Amount) string {
+	s := a.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+
+	if l.GroupSep != "" && len(intPart) > 3 {
+		var b strings.Builder
+		head := len(intPart) % 3
+		if head > 0 {
+			b.WriteString(intPart[:head])
+		}
+		for i := head; i < len(intPart); i += 3 {
+			if b.Len() > 0 {
+				b.WriteString(l.GroupSep)
+			}
+			b.WriteString(intPart[i : i+3])
+		}
+		intPart = b.String()
+	}
+	return sign + intPart + l.DecimalSep + frac
+}
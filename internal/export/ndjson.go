@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	columns []Column
+}
+
+func newNDJSONWriter(w io.Writer, columns []Column) *ndjsonWriter {
+	return &ndjsonWriter{w: bufio.NewWriter(w), columns: columns}
+}
+
+// Write writes one JSON object with the selected columns in their requested
+// order.
+func (nw *ndjsonWriter) Write(sub *model.SubscriptionResponse) error {
+	nw.w.WriteByte('{')
+	for i, c := range nw.columns {
+		if i > 0 {
+			nw.w.WriteByte(',')
+		}
+		nw.w.WriteString(strconv.Quote(c.Name))
+		nw.w.WriteByte(':')
+
+		v := c.get(sub)
+		switch {
+		case v.null:
+			nw.w.WriteString("null")
+		case v.kind == kindInt:
+			nw.w.WriteString(strconv.FormatInt(v.num, 10))
+		case v.kind == kindMoney:
+			nw.w.WriteString(model.Amount(v.num).String())
+		case v.kind == kindDate, v.kind == kindDateTime:
+			nw.w.WriteString(strconv.Quote(v.time.Format(time.RFC3339)))
+		default:
+			b, err := json.Marshal(v.str)
+			if err != nil {
+				return err
+			}
+			nw.w.Write(b)
+		}
+	}
+	_, err := nw.w.WriteString("}\n")
+	return err
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nw.w.Flush()
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+type AuditHandler struct {
+	queryService service.AuditQueryService
+}
+
+func NewAuditHandler(queryService service.AuditQueryService) *AuditHandler {
+	return &AuditHandler{queryService: queryService}
+}
+
+// GetSubscriptionHistory godoc
+// @Summary      Get change history of a subscription
+// @Description  Returns audit events of a subscription (create, update, delete), newest first,
+// @Description  without the request metadata shown by GET /admin/audit-events
+// @Tags         audit
+// @Produce      json
+// @Param        id      path      int  true   "Subscription ID"
+// @Param        limit   query     int  false  "Page size (1-100, default 20)"
+// @Param        offset  query     int  false  "Offset"
+// @Success      200  {object}  model.SubscriptionHistoryResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/history [get]
+func (h *AuditHandler) GetSubscriptionHistory(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: GetSubscriptionHistory() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	var params model.ListAuditEventsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		log.Warnf("Invalid history query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	res, err := h.queryService.History(c.Request.Context(), uint(id), params.Limit, params.Offset)
+	if err != nil {
+		log.Errorf("Failed to get history for subscription ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d audit events for subscription ID=%d", len(res.Items), id)
+	c.JSON(http.StatusOK, res)
+}
+
+// List godoc
+// @Summary      Query audit events
+// @Description  Returns audit events across all subscriptions, newest first
+// @Tags         audit
+// @Produce      json
+// @Param        X-Admin-Token    header    string  true   "Admin token"
+// @Param        entity_type      query     string  false  "Entity type" Enums(subscription)
+// @Param        subscription_id  query     int     false  "Subscription ID"
+// @Param        action           query     string  false  "Action" Enums(create, update, delete, restore, purge, pause, resume, cancel)
+// @Param        actor            query     string  false  "Actor"
+// @Param        request_id       query     string  false  "Request ID"
+// @Param        from             query     string  false  "Created at or after (RFC3339)"
+// @Param        to               query     string  false  "Created at or before (RFC3339)"
+// @Param        limit            query     int     false  "Page size (1-100, default 20)"
+// @Param        offset           query     int     false  "Offset"
+// @Success      200  {object}  model.AuditEventListResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      403  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/audit-events [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: Audit List() called | query=%s", c.Request.URL.RawQuery)
+
+	var params model.ListAuditEventsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		log.Warnf("Invalid audit query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	filter := model.AuditFilter{
+		EntityID: params.SubscriptionID,
+		From:     params.From,
+		To:       params.To,
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+	}
+	if params.SubscriptionID != nil {
+		entityType := model.AuditEntitySubscription
+		filter.EntityType = &entityType
+	}
+	if params.EntityType != "" {
+		filter.EntityType = &params.EntityType
+	}
+	if params.Action != "" {
+		filter.Action = &params.Action
+	}
+	if params.Actor != "" {
+		filter.Actor = &params.Actor
+	}
+	if params.RequestID != "" {
+		filter.RequestID = &params.RequestID
+	}
+
+	res, err := h.queryService.List(c.Request.Context(), filter)
+	if err != nil {
+		log.Errorf("Failed to query audit events: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d audit events", len(res.Items))
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/calendar"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+type CalendarHandler struct {
+	calendarService service.CalendarService
+}
+
+func NewCalendarHandler(calendarService service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// Feed godoc
+// @Summary      Calendar feed of a user
+// @Description  Returns an iCalendar (RFC 5545) feed with a recurring event on every billing date of the user's subscriptions and an event on each end date. Subscribe to this URL from a calendar app
+// @Tags         calendar
+// @Produce      text/calendar
+// @Param        user_id  path      string  true  "User ID"
+// @Param        token    query     string  true  "Calendar token issued by POST /admin/users/{user_id}/calendar-token"
+// @Success      200  {string}  string  "iCalendar feed"
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /users/{user_id}/calendar.ics [get]
+func (h *CalendarHandler) Feed(c *gin.Context) {
+	log := logger.Get()
+	userIDParam := c.Param("user_id")
+	log.Infof("Handler: Calendar Feed() called for user_id=%s", userIDParam)
+
+	userID, err := uuid.Parse(userIDParam)
+	if err != nil {
+		log.Warnf("Invalid user_id: %s", userIDParam)
+		_ = c.Error(model.ErrInvalidUserID)
+		return
+	}
+
+	feed, err := h.calendarService.Feed(c.Request.Context(), userID, c.Query("token"))
+	if err != nil {
+		log.Warnf("Failed to build calendar for user_id=%s: %v", userID, err)
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Header("Content-Disposition", `inline; filename="subscriptions.ics"`)
+	c.Data(http.StatusOK, calendar.ContentType, feed)
+}
+
+// IssueToken godoc
+// @Summary      Issue a calendar token
+// @Description  Creates a new secret token for the calendar feed of a user. The previous token stops working
+// @Tags         calendar
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Param        user_id        path      string  true  "User ID"
+// @Success      201  {object}  model.CalendarTokenResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/users/{user_id}/calendar-token [post]
+func (h *CalendarHandler) IssueToken(c *gin.Context) {
+	log := logger.Get()
+	userIDParam := c.Param("user_id")
+	log.Infof("Handler: Calendar IssueToken() called for user_id=%s", userIDParam)
+
+	userID, err := uuid.Parse(userIDParam)
+	if err != nil {
+		log.Warnf("Invalid user_id: %s", userIDParam)
+		_ = c.Error(model.ErrInvalidUserID)
+		return
+	}
+
+	res, err := h.calendarService.IssueToken(c.Request.Context(), userID)
+	if err != nil {
+		log.Errorf("Failed to issue calendar token for user_id=%s: %v", userID, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Calendar token issued for user_id=%s", userID)
+	c.JSON(http.StatusCreated, res)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+var errInvalidChurnRange = domainerr.Validation("invalid_date_range", "to cannot be before from")
+
+type CancellationHandler struct {
+	queryService service.CancellationQueryService
+}
+
+func NewCancellationHandler(queryService service.CancellationQueryService) *CancellationHandler {
+	return &CancellationHandler{queryService: queryService}
+}
+
+// Churn godoc
+// @Summary      Churn reasons by service
+// @Description  Counts subscription cancellations made in [from, to] per service and reason, most frequent reasons first
+// @Tags         subscriptions
+// @Produce      json
+// @Param        service_name  query     string  false  "Service Name"
+// @Param        from          query     string  false  "Cancellations made at or after (RFC3339)"
+// @Param        to            query     string  false  "Cancellations made at or before (RFC3339)"
+// @Success      200  {object}  model.ChurnResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/churn [get]
+func (h *CancellationHandler) Churn(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: Churn() called | query=%s", c.Request.URL.RawQuery)
+
+	var params model.ChurnParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		log.Warnf("Invalid churn query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+	if params.From != nil && params.To != nil && params.To.Before(*params.From) {
+		log.Warnf("Invalid churn range: from=%s to=%s", params.From, params.To)
+		_ = c.Error(errInvalidChurnRange)
+		return
+	}
+
+	filter := model.ChurnFilter{From: params.From, To: params.To}
+	if params.ServiceName != "" {
+		filter.ServiceName = &params.ServiceName
+	}
+
+	res, err := h.queryService.Churn(c.Request.Context(), filter)
+	if err != nil {
+		log.Errorf("Failed to calculate churn: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Churn() result: %d cancellations across %d services", res.Total, len(res.Services))
+	c.JSON(http.StatusOK, res)
+}
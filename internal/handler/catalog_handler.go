@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+type CatalogHandler struct {
+	catalogService service.CatalogService
+}
+
+func NewCatalogHandler(catalogService service.CatalogService) *CatalogHandler {
+	return &CatalogHandler{catalogService: catalogService}
+}
+
+// List godoc
+// @Summary      List the service catalog
+// @Description  Returns every catalog entry with its aliases, ordered by name
+// @Tags         services
+// @Produce      json
+// @Success      200  {array}   model.Service
+// @Failure      500  {object}  model.Problem
+// @Router       /services [get]
+func (h *CatalogHandler) List(c *gin.Context) {
+	log := logger.Get()
+	log.Info("Handler: Catalog List() called")
+
+	services, err := h.catalogService.List(c.Request.Context())
+	if err != nil {
+		log.Errorf("Failed to list services: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d services", len(services))
+	c.JSON(http.StatusOK, services)
+}
+
+// Get godoc
+// @Summary      Get a catalog entry
+// @Tags         services
+// @Produce      json
+// @Param        id   path      int  true  "Service ID"
+// @Success      200  {object}  model.Service
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /services/{id} [get]
+func (h *CatalogHandler) Get(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Catalog Get() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid service ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	svc, err := h.catalogService.Get(c.Request.Context(), uint(id))
+	if err != nil {
+		log.Errorf("Failed to get service ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, svc)
+}
+
+// Create godoc
+// @Summary      Add a catalog entry
+// @Description  Adds a service with its canonical name, aliases and defaults for new subscriptions.
+// @Description  Existing subscriptions are not linked until POST /admin/services/match
+// @Tags         services
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string                true  "Admin token"
+// @Param        service        body      model.ServiceRequest  true  "Catalog entry"
+// @Success      201  {object}  model.Service
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/services [post]
+func (h *CatalogHandler) Create(c *gin.Context) {
+	log := logger.Get()
+	log.Info("Handler: Catalog Create() called")
+
+	var req model.ServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("Invalid service request: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	svc, err := h.catalogService.Create(c.Request.Context(), &req)
+	if err != nil {
+		log.Errorf("Failed to create service: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Service created with ID=%d", svc.ID)
+	c.JSON(http.StatusCreated, svc)
+}
+
+// Update godoc
+// @Summary      Replace a catalog entry
+// @Description  Replaces the entry; subscriptions linked to it take the new name, each rename of a subscription
+// @Description  that is not deleted is audited and published as subscription.updated
+// @Tags         services
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string                true  "Admin token"
+// @Param        id             path      int                   true  "Service ID"
+// @Param        service        body      model.ServiceRequest  true  "Catalog entry"
+// @Success      200  {object}  model.Service
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/services/{id} [put]
+func (h *CatalogHandler) Update(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Catalog Update() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid service ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	var req model.ServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("Invalid service request for ID=%d: %v", id, err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	svc, err := h.catalogService.Update(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		log.Errorf("Failed to update service ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Service ID=%d updated successfully", id)
+	c.JSON(http.StatusOK, svc)
+}
+
+// Delete godoc
+// @Summary      Delete a catalog entry
+// @Description  Removes an entry that no subscription, including a deleted one, refers to
+// @Tags         services
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Param        id             path      int     true  "Service ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/services/{id} [delete]
+func (h *CatalogHandler) Delete(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Catalog Delete() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid service ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	if err := h.catalogService.Delete(c.Request.Context(), uint(id)); err != nil {
+		log.Errorf("Failed to delete service ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Service ID=%d deleted successfully", id)
+	c.Status(http.StatusNoContent)
+}
+
+// Merge godoc
+// @Summary      Merge a catalog entry into another
+// @Description  Moves the subscriptions of the entry service_id to the entry {id} under its name, adds the name
+// @Description  and aliases of service_id to the aliases of {id} and deletes service_id. Every moved subscription
+// @Description  that is not deleted gets an audit event and a subscription.updated event
+// @Tags         services
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string                     true  "Admin token"
+// @Param        id             path      int                        true  "Service ID to keep"
+// @Param        merge          body      model.MergeServiceRequest  true  "Service to merge"
+// @Success      200  {object}  model.Service
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/services/{id}/merge [post]
+func (h *CatalogHandler) Merge(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Catalog Merge() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid service ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	var req model.MergeServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("Invalid merge request for ID=%d: %v", id, err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	svc, err := h.catalogService.Merge(c.Request.Context(), uint(id), req.ServiceID)
+	if err != nil {
+		log.Errorf("Failed to merge service ID=%d into ID=%d: %v", req.ServiceID, id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Service ID=%d merged into ID=%d", req.ServiceID, id)
+	c.JSON(http.StatusOK, svc)
+}
+
+// Match godoc
+// @Summary      Link subscriptions to the catalog
+// @Description  Matches the service_name of subscriptions without a service_id against catalog names and aliases,
+// @Description  links and renames the matching ones and reports the names that match no entry.
+// @Description  Every linked subscription that is not deleted gets an audit event and a subscription.updated event
+// @Tags         services
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Success      200  {object}  model.ServiceMatchReport
+// @Failure      401  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/services/match [post]
+func (h *CatalogHandler) Match(c *gin.Context) {
+	log := logger.Get()
+	log.Info("Handler: Catalog Match() called")
+
+	report, err := h.catalogService.LinkSubscriptions(c.Request.Context())
+	if err != nil {
+		log.Errorf("Failed to link subscriptions to services: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Linked %d service names, %d unmatched", len(report.Matched), len(report.Unmatched))
+	c.JSON(http.StatusOK, report)
+}
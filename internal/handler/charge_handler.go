@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+type ChargeHandler struct {
+	queryService service.ChargeQueryService
+}
+
+func NewChargeHandler(queryService service.ChargeQueryService) *ChargeHandler {
+	return &ChargeHandler{queryService: queryService}
+}
+
+// GetBySubscriptionID godoc
+// @Summary      Get charge history of a subscription
+// @Description  Returns the charges materialized by the renewal worker for a subscription, oldest first
+// @Tags         charges
+// @Produce      json
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {array}   model.Charge
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/charges [get]
+func (h *ChargeHandler) GetBySubscriptionID(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Charge GetBySubscriptionID() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	charges, err := h.queryService.GetBySubscriptionID(c.Request.Context(), uint(id))
+	if err != nil {
+		log.Errorf("Failed to get charges for subscription ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d charges for subscription ID=%d", len(charges), id)
+	c.JSON(http.StatusOK, charges)
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+var errInvalidID = domainerr.Validation("invalid_id", "invalid id")
+
+// unknownFieldPrefix starts the error encoding/json returns for fields
+// rejected by Decoder.DisallowUnknownFields.
+const unknownFieldPrefix = "json: unknown field "
+
+// UseRequestFieldNames makes the validator report fields by their JSON (or,
+// for query parameters, form) names instead of Go struct field names.
+func UseRequestFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return f.Name
+	})
+}
+
+// bindJSONSlice decodes a JSON array and validates every item, reporting
+// failing fields with their position, e.g. "[2].rate".
+func bindJSONSlice[T any](c *gin.Context, items *[]T) error {
+	if err := json.NewDecoder(c.Request.Body).Decode(items); err != nil {
+		return invalidRequest(err)
+	}
+
+	var fields []domainerr.FieldError
+	var errs validator.ValidationErrors
+	for i := range *items {
+		if err := binding.Validator.ValidateStruct(&(*items)[i]); err != nil {
+			if !errors.As(err, &errs) {
+				return invalidRequest(err)
+			}
+			fields = append(fields, fieldErrors(errs, fmt.Sprintf("[%d].", i))...)
+		}
+	}
+	if len(fields) > 0 {
+		return domainerr.InvalidFields(nil, fields)
+	}
+	return nil
+}
+
+// invalidRequest turns request parsing and binding failures into validation
+// errors with field details. Domain errors returned by parsers are kept as
+// they are; raw decoder messages are not passed to clients.
+func invalidRequest(err error) error {
+	if _, ok := domainerr.As(err); ok {
+		return err
+	}
+
+	var sliceErrs binding.SliceValidationError
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	var timeErr *time.ParseError
+	var numErr *strconv.NumError
+
+	switch {
+	case errors.As(err, &sliceErrs):
+		// gin drops the positions of the failing items; use bindJSONSlice
+		// where they matter.
+		var fields []domainerr.FieldError
+		for _, itemErr := range sliceErrs {
+			if errors.As(itemErr, &validationErrs) {
+				fields = append(fields, fieldErrors(validationErrs, "")...)
+			}
+		}
+		return domainerr.InvalidFields(err, fields)
+	case errors.As(err, &validationErrs):
+		return domainerr.InvalidFields(err, fieldErrors(validationErrs, ""))
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return domainerr.InvalidFields(err, []domainerr.FieldError{{
+			Field:   field,
+			Rule:    "type",
+			Message: fmt.Sprintf("must be of type %s", jsonTypeName(typeErr.Type)),
+		}})
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		return domainerr.InvalidFields(err, []domainerr.FieldError{{
+			Field:   strings.Trim(strings.TrimPrefix(err.Error(), unknownFieldPrefix), `"`),
+			Rule:    "unknown",
+			Message: "is not a known field",
+		}})
+	case errors.Is(err, io.EOF):
+		return domainerr.Wrap(err, domainerr.KindValidation, "empty_body", "request body is empty")
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return domainerr.Wrap(err, domainerr.KindValidation, "malformed_json", "request body is not valid JSON")
+	case errors.As(err, &timeErr):
+		return domainerr.Wrap(err, domainerr.KindValidation, "invalid_request",
+			fmt.Sprintf("invalid time %q, expected RFC3339", timeErr.Value))
+	case errors.As(err, &numErr):
+		return domainerr.Wrap(err, domainerr.KindValidation, "invalid_request",
+			fmt.Sprintf("invalid number %q", numErr.Num))
+	default:
+		return domainerr.Wrap(err, domainerr.KindValidation, "invalid_request", "invalid request")
+	}
+}
+
+func fieldErrors(errs validator.ValidationErrors, prefix string) []domainerr.FieldError {
+	fields := make([]domainerr.FieldError, len(errs))
+	for i, fe := range errs {
+		fields[i] = domainerr.FieldError{
+			Field:   prefix + fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+	}
+	return fields
+}
+
+// fieldPath strips the struct name from the validator namespace, so nested
+// fields come out as "items[0].price".
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be greater than or equal to " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "lte":
+		return "must be less than or equal to " + fe.Param()
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "len":
+		return "must have length " + fe.Param()
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "nefield":
+		return "must differ from " + snakeCase(fe.Param())
+	case "datetime":
+		return "must be a date in format " + fe.Param()
+	default:
+		return "is invalid"
+	}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// snakeCase converts a Go field name referenced by a cross-field rule to its
+// request name, e.g. FromCurrency -> from_currency.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// RequestValidator checks requests that do not come through gin binding,
+// such as imported rows or patched documents, with the same rules and error
+// details as the API.
+type RequestValidator struct{}
+
+func (RequestValidator) ValidateStruct(req any) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return invalidRequest(err)
+	}
+	return nil
+}
+
+func (RequestValidator) TranslateError(err error) error {
+	return invalidRequest(err)
+}
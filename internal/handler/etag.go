@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+var errInvalidIfMatch = domainerr.Validation("invalid_if_match", "invalid If-Match header")
+
+// etag formats a subscription version as a strong entity tag.
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// parseIfMatch returns the version from the If-Match header, or nil when the
+// header is absent or "*". Weak tags (W/"3") are accepted as well.
+func parseIfMatch(c *gin.Context) (*int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return nil, errInvalidIfMatch
+	}
+	return &version, nil
+}
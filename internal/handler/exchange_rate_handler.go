@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+type ExchangeRateHandler struct {
+	queryService   service.ExchangeRateQueryService
+	commandService service.ExchangeRateCommandService
+}
+
+func NewExchangeRateHandler(queryService service.ExchangeRateQueryService, commandService service.ExchangeRateCommandService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{queryService: queryService, commandService: commandService}
+}
+
+// GetAll godoc
+// @Summary      List exchange rates
+// @Description  Returns all exchange rates, newest first, optionally only those involving a currency
+// @Tags         exchange-rates
+// @Produce      json
+// @Param        currency  query     string  false  "Currency code" Enums(RUB, USD, EUR)
+// @Success      200  {array}   model.ExchangeRate
+// @Failure      500  {object}  model.Problem
+// @Router       /exchange-rates [get]
+func (h *ExchangeRateHandler) GetAll(c *gin.Context) {
+	log := logger.Get()
+	currency := strings.ToUpper(c.Query("currency"))
+	log.Infof("Handler: ExchangeRate GetAll() called | currency=%s", currency)
+
+	var currencyPtr *string
+	if currency != "" {
+		currencyPtr = &currency
+	}
+
+	rates, err := h.queryService.GetAll(c.Request.Context(), currencyPtr)
+	if err != nil {
+		log.Errorf("Failed to get exchange rates: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d exchange rates", len(rates))
+	c.JSON(http.StatusOK, rates)
+}
+
+// Upsert godoc
+// @Summary      Create or update exchange rates
+// @Description  Stores exchange rates effective from the given dates. An existing rate for the same pair and date is replaced
+// @Tags         exchange-rates
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string                       true  "Admin token"
+// @Param        rates          body      []model.ExchangeRateRequest  true  "Exchange rates"
+// @Success      200  {array}   model.ExchangeRate
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/exchange-rates [post]
+func (h *ExchangeRateHandler) Upsert(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: ExchangeRate Upsert() called from %s", c.ClientIP())
+
+	var reqs []model.ExchangeRateRequest
+	if err := bindJSONSlice(c, &reqs); err != nil {
+		log.Warnf("Invalid exchange rate request: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	rates, err := h.commandService.Upsert(c.Request.Context(), reqs)
+	if err != nil {
+		log.Errorf("Failed to store exchange rates: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Stored %d exchange rates", len(rates))
+	c.JSON(http.StatusOK, rates)
+}
+
+// ImportCSV godoc
+// @Summary      Import exchange rates from CSV
+// @Description  Loads rates from CSV rows "from_currency,to_currency,rate,effective_date" (date as YYYY-MM-DD). A header row is optional
+// @Tags         exchange-rates
+// @Accept       text/csv
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Param        file           body      string  true  "CSV content"
+// @Success      200  {object}  model.ExchangeRateImportResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/exchange-rates/import [post]
+func (h *ExchangeRateHandler) ImportCSV(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: ExchangeRate ImportCSV() called from %s", c.ClientIP())
+
+	count, err := h.commandService.ImportCSV(c.Request.Context(), c.Request.Body)
+	if err != nil {
+		log.Errorf("Failed to import exchange rates: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Imported %d exchange rates", count)
+	c.JSON(http.StatusOK, model.ExchangeRateImportResponse{Imported: count})
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// GetPreferences godoc
+// @Summary      Get reminder settings of a user
+// @Description  Returns the email address and the kinds of reminders the user receives
+// @Tags         notifications
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Param        user_id        path      string  true  "User ID"
+// @Success      200  {object}  model.NotificationPreference
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/users/{user_id}/notification-preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	log := logger.Get()
+	userIDParam := c.Param("user_id")
+	log.Infof("Handler: Notification GetPreferences() called for user_id=%s", userIDParam)
+
+	userID, err := uuid.Parse(userIDParam)
+	if err != nil {
+		log.Warnf("Invalid user_id: %s", userIDParam)
+		_ = c.Error(model.ErrInvalidUserID)
+		return
+	}
+
+	pref, err := h.notificationService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		log.Warnf("Failed to get notification preferences for user_id=%s: %v", userID, err)
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pref)
+}
+
+// UpdatePreferences godoc
+// @Summary      Set reminder settings of a user
+// @Description  Replaces the reminder settings of the user. Reminders are emailed before billing dates (renewal) and end dates (expiration) of the user's subscriptions
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string                                      true  "Admin token"
+// @Param        user_id        path      string                                      true  "User ID"
+// @Param        input          body      model.UpdateNotificationPreferencesRequest  true  "Reminder settings"
+// @Success      200  {object}  model.NotificationPreference
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/users/{user_id}/notification-preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	log := logger.Get()
+	userIDParam := c.Param("user_id")
+	log.Infof("Handler: Notification UpdatePreferences() called for user_id=%s", userIDParam)
+
+	userID, err := uuid.Parse(userIDParam)
+	if err != nil {
+		log.Warnf("Invalid user_id: %s", userIDParam)
+		_ = c.Error(model.ErrInvalidUserID)
+		return
+	}
+
+	var req model.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("Invalid notification preferences request: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	pref, err := h.notificationService.UpdatePreferences(c.Request.Context(), userID, &req)
+	if err != nil {
+		log.Errorf("Failed to update notification preferences for user_id=%s: %v", userID, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Notification preferences updated for user_id=%s", userID)
+	c.JSON(http.StatusOK, pref)
+}
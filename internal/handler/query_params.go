@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+func toListQuery(p model.ListSubscriptionsParams) (model.SubscriptionListQuery, error) {
+	query := model.SubscriptionListQuery{
+		Filter: toSubscriptionFilter(p),
+		Limit:  p.Limit,
+		Offset: p.Offset,
+		Cursor: p.Cursor,
+		Order:  model.SortAsc,
+
+		IncludeDeleted: p.IncludeDeleted,
+	}
+
+	if p.Sort != "" {
+		field := p.Sort
+		if strings.HasPrefix(field, "-") {
+			field = strings.TrimPrefix(field, "-")
+			query.Order = model.SortDesc
+		}
+		if !slices.Contains(model.SubscriptionSortFields, field) {
+			return query, domainerr.Validation("unsupported_sort_field", fmt.Sprintf("unsupported sort field: %s", field))
+		}
+		query.Sort = field
+	}
+
+	return query, nil
+}
+
+func toSubscriptionFilter(p model.ListSubscriptionsParams) model.SubscriptionFilter {
+	filter := model.SubscriptionFilter{
+		PriceMin:      p.PriceMin,
+		PriceMax:      p.PriceMax,
+		StartDateFrom: p.StartDateFrom,
+		StartDateTo:   p.StartDateTo,
+		EndDateFrom:   p.EndDateFrom,
+		EndDateTo:     p.EndDateTo,
+		ActiveAt:      p.ActiveAt,
+		ServiceID:     p.ServiceID,
+
+		TrialEndDateFrom: p.TrialEndDateFrom,
+		TrialEndDateTo:   p.TrialEndDateTo,
+	}
+	if p.InTrial {
+		now := time.Now()
+		filter.InTrialAt = &now
+	}
+	if p.ServiceName != "" {
+		filter.ServiceName = &p.ServiceName
+	}
+	if p.UserID != "" {
+		if uid, err := uuid.Parse(p.UserID); err == nil {
+			filter.UserID = &uid
+		}
+	}
+	return filter
+}
+
+func parseCostQuery(c *gin.Context) (model.CostQuery, error) {
+	query := model.CostQuery{
+		Mode: model.SumMode(c.DefaultQuery("mode", string(model.SumModeBilled))),
+	}
+
+	startDateStr := c.Query("start_date")
+	endDateStr := c.Query("end_date")
+	if startDateStr == "" || endDateStr == "" {
+		return query, domainerr.Validation("date_range_required", "start_date and end_date are required")
+	}
+
+	var err error
+	if query.StartDate, err = time.Parse(time.RFC3339, startDateStr); err != nil {
+		return query, domainerr.Validation("invalid_start_date", "invalid start_date format")
+	}
+	if query.EndDate, err = time.Parse(time.RFC3339, endDateStr); err != nil {
+		return query, domainerr.Validation("invalid_end_date", "invalid end_date format")
+	}
+	if query.EndDate.Before(query.StartDate) {
+		return query, model.ErrInvalidDateRange
+	}
+	if query.EndDate.After(query.StartDate.AddDate(model.MaxCostRangeYears, 0, 0)) {
+		return query, model.ErrCostRangeTooLong
+	}
+	if !query.Mode.Valid() {
+		return query, domainerr.Validation("invalid_mode", "invalid mode")
+	}
+
+	query.TargetCurrency = strings.ToUpper(c.DefaultQuery("target_currency", model.DefaultCurrency))
+	if !model.IsSupportedCurrency(query.TargetCurrency) {
+		return query, domainerr.Validation("unsupported_currency", "unsupported target_currency")
+	}
+
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return query, model.ErrInvalidUserID
+		}
+		query.UserID = &userID
+	}
+	if serviceName := c.Query("service_name"); serviceName != "" {
+		query.ServiceName = &serviceName
+	}
+
+	return query, nil
+}
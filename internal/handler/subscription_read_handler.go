@@ -1,12 +1,18 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/export"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
 	"github.com/winnamu6/go-subscription-service/internal/service"
 )
 
@@ -19,23 +25,189 @@ func NewSubscriptionReadHandler(queryService service.SubscriptionQueryService) *
 }
 
 // GetAll godoc
+// @Summary      List subscriptions
+// @Description  Returns a page of subscriptions. Supports offset and keyset (cursor) pagination, filters and sorting
+// @Tags         subscriptions
+// @Produce      json
+// @Param        service_name         query     string  false  "Service Name, catalog aliases select the same service"
+// @Param        service_id           query     int     false  "Service catalog entry ID"
+// @Param        user_id              query     string  false  "User ID"
+// @Param        price_min            query     number  false  "Minimum price (decimal, e.g. 199.99)"
+// @Param        price_max            query     number  false  "Maximum price (decimal, e.g. 199.99)"
+// @Param        start_date_from      query     string  false  "Start date lower bound (RFC3339 format)"
+// @Param        start_date_to        query     string  false  "Start date upper bound (RFC3339 format)"
+// @Param        end_date_from        query     string  false  "End date lower bound (RFC3339 format)"
+// @Param        end_date_to          query     string  false  "End date upper bound (RFC3339 format)"
+// @Param        active_at            query     string  false  "Only subscriptions active at this date (RFC3339 format)"
+// @Param        in_trial             query     bool    false  "Only subscriptions currently in their trial period"
+// @Param        trial_end_date_from  query     string  false  "Trial end date lower bound (RFC3339 format)"
+// @Param        trial_end_date_to    query     string  false  "Trial end date upper bound (RFC3339 format)"
+// @Param        sort                 query     string  false  "Sort field, prefix with - for descending order (e.g. -start_date)"
+// @Param        limit                query     int     false  "Page size (1-100, default 20)"
+// @Param        offset               query     int     false  "Number of rows to skip, ignored when cursor is set"
+// @Param        cursor               query     string  false  "Cursor from next_cursor of the previous page"
+// @Param        include_deleted      query     bool    false  "Include soft-deleted subscriptions"
+// @Success      200  {object}  model.SubscriptionListResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions [get]
 func (h *SubscriptionReadHandler) GetAll(c *gin.Context) {
 	log := logger.Get()
-	log.Info("Handler: GetAll() called")
+	log.Infof("Handler: GetAll() called | query=%s", c.Request.URL.RawQuery)
 
+	h.list(c, false)
+}
+
+// GetDeleted godoc
+// @Summary      List deleted subscriptions
+// @Description  Returns a page of soft-deleted subscriptions. Accepts the same filters, sorting and pagination as the list endpoint
+// @Tags         subscriptions
+// @Produce      json
+// @Param        service_name  query     string  false  "Service Name"
+// @Param        user_id       query     string  false  "User ID"
+// @Param        sort          query     string  false  "Sort field, prefix with - for descending order (e.g. -start_date)"
+// @Param        limit         query     int     false  "Page size (1-100, default 20)"
+// @Param        offset        query     int     false  "Number of rows to skip, ignored when cursor is set"
+// @Param        cursor        query     string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  model.SubscriptionListResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/deleted [get]
+func (h *SubscriptionReadHandler) GetDeleted(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: GetDeleted() called | query=%s", c.Request.URL.RawQuery)
+
+	h.list(c, true)
+}
+
+func (h *SubscriptionReadHandler) list(c *gin.Context, onlyDeleted bool) {
+	log := logger.Get()
 	ctx := c.Request.Context()
-	subs, err := h.queryService.GetAll(ctx)
+
+	var params model.ListSubscriptionsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		log.Warnf("Invalid list query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	query, err := toListQuery(params)
+	if err != nil {
+		log.Warnf("Invalid list query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+	query.OnlyDeleted = onlyDeleted
+
+	subs, err := h.queryService.GetAll(ctx, query)
 	if err != nil {
 		log.Errorf("Failed to get all subscriptions: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
-	log.Infof("Successfully retrieved %d subscriptions", len(subs))
+	log.Infof("Successfully retrieved %d of %d subscriptions", len(subs.Items), subs.Total)
 	c.JSON(http.StatusOK, subs)
 }
 
+// Export godoc
+// @Summary      Export subscriptions
+// @Description  Streams subscriptions matching the listing filters as CSV, XLSX or JSON Lines. Pagination parameters are ignored. The locale changes date and amount formatting in CSV and XLSX (and the CSV delimiter to ";" for ru); JSON Lines always use RFC3339 dates and decimal amounts
+// @Tags         subscriptions
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/x-ndjson
+// @Param        format               query     string  true   "File format"                                                                                  Enums(csv, xlsx, ndjson)
+// @Param        columns              query     string  false  "Comma-separated columns (default: id,service_name,price,currency,user_id,start_date,end_date,billing_period,billing_interval; also service_id, trial_end_date, trial_price, version, created_at, updated_at, deleted_at)"
+// @Param        locale               query     string  false  "Date and amount formatting, ISO by default"                                                    Enums(en, ru)
+// @Param        service_name         query     string  false  "Service Name, catalog aliases select the same service"
+// @Param        service_id           query     int     false  "Service catalog entry ID"
+// @Param        user_id              query     string  false  "User ID"
+// @Param        price_min            query     number  false  "Minimum price (decimal, e.g. 199.99)"
+// @Param        price_max            query     number  false  "Maximum price (decimal, e.g. 199.99)"
+// @Param        start_date_from      query     string  false  "Start date lower bound (RFC3339 format)"
+// @Param        start_date_to        query     string  false  "Start date upper bound (RFC3339 format)"
+// @Param        end_date_from        query     string  false  "End date lower bound (RFC3339 format)"
+// @Param        end_date_to          query     string  false  "End date upper bound (RFC3339 format)"
+// @Param        active_at            query     string  false  "Only subscriptions active at this date (RFC3339 format)"
+// @Param        in_trial             query     bool    false  "Only subscriptions currently in their trial period"
+// @Param        trial_end_date_from  query     string  false  "Trial end date lower bound (RFC3339 format)"
+// @Param        trial_end_date_to    query     string  false  "Trial end date upper bound (RFC3339 format)"
+// @Param        sort                 query     string  false  "Sort field, prefix with - for descending order (e.g. -start_date)"
+// @Param        include_deleted      query     bool    false  "Include soft-deleted subscriptions"
+// @Success      200  {file}    file
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/export [get]
+func (h *SubscriptionReadHandler) Export(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: Export() called | query=%s", c.Request.URL.RawQuery)
+
+	var params model.ExportSubscriptionsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		log.Warnf("Invalid export query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	query, err := toListQuery(params.ListSubscriptionsParams)
+	if err != nil {
+		log.Warnf("Invalid export query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+	columns, err := export.ParseColumns(params.Columns)
+	if err != nil {
+		log.Warnf("Invalid export columns: %v", err)
+		_ = c.Error(err)
+		return
+	}
+	locale, err := export.LookupLocale(params.Locale)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	filename := fmt.Sprintf("subscriptions-%s.%s", time.Now().Format("20060102"), params.Format)
+	c.Header("Content-Type", export.ContentType(params.Format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Errors past this point cannot be reported to the client: the response
+	// has already started, so the download ends early.
+	w, err := export.NewWriter(c.Writer, params.Format, columns, locale)
+	if err != nil {
+		log.Errorf("Failed to start export: %v", err)
+		return
+	}
+	count := 0
+	err = h.queryService.Export(c.Request.Context(), query, func(sub *model.SubscriptionResponse) error {
+		count++
+		return w.Write(sub)
+	})
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		log.Errorf("Export interrupted after %d rows: %v", count, err)
+		return
+	}
+
+	log.Infof("Exported %d subscriptions as %s", count, params.Format)
+}
+
 // GetByID godoc
+// @Summary      Get subscription by ID
+// @Description  Returns a subscription by its unique ID
+// @Tags         subscriptions
+// @Produce      json
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {object}  model.SubscriptionResponse
+// @Header       200  {string}  ETag  "Version of the subscription, send it back in If-Match"
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id} [get]
 func (h *SubscriptionReadHandler) GetByID(c *gin.Context) {
 	log := logger.Get()
 	idParam := c.Param("id")
@@ -45,27 +217,102 @@ func (h *SubscriptionReadHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
 		log.Warnf("Invalid subscription ID: %s", idParam)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(errInvalidID)
 		return
 	}
 
 	sub, err := h.queryService.GetByID(ctx, uint(id))
 	if err != nil {
 		log.Errorf("Failed to get subscription ID=%d: %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	if sub == nil {
-		log.Warnf("Subscription ID=%d not found", id)
-		c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
+		_ = c.Error(err)
 		return
 	}
 
 	log.Infof("Subscription ID=%d retrieved successfully", id)
+	c.Header("ETag", etag(sub.Version))
 	c.JSON(http.StatusOK, sub)
 }
 
+// GetPriceHistory godoc
+// @Summary      Get price history of a subscription
+// @Description  Returns every recorded price with the date it became (or becomes) effective, oldest first
+// @Tags         subscriptions
+// @Produce      json
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {array}   model.SubscriptionPrice
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/prices [get]
+func (h *SubscriptionReadHandler) GetPriceHistory(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: GetPriceHistory() called for ID=%s", idParam)
+
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	prices, err := h.queryService.GetPriceHistory(ctx, uint(id))
+	if err != nil {
+		log.Errorf("Failed to get price history for ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d prices for subscription ID=%d", len(prices), id)
+	c.JSON(http.StatusOK, prices)
+}
+
+// GetPauseHistory godoc
+// @Summary      Get pause history of a subscription
+// @Description  Returns every pause of the subscription, oldest first. resume_at is empty while a pause has no end date
+// @Tags         subscriptions
+// @Produce      json
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {array}   model.SubscriptionPause
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/pauses [get]
+func (h *SubscriptionReadHandler) GetPauseHistory(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: GetPauseHistory() called for ID=%s", idParam)
+
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	pauses, err := h.queryService.GetPauseHistory(ctx, uint(id))
+	if err != nil {
+		log.Errorf("Failed to get pause history for ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d pauses for subscription ID=%d", len(pauses), id)
+	c.JSON(http.StatusOK, pauses)
+}
+
 // GetByUserID godoc
+// @Summary      Get subscriptions by User ID
+// @Description  Returns all subscriptions associated with a given user
+// @Tags         subscriptions
+// @Produce      json
+// @Param        user_id  path      string  true  "User ID"
+// @Success      200  {array}   model.SubscriptionResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/user/{user_id} [get]
 func (h *SubscriptionReadHandler) GetByUserID(c *gin.Context) {
 	log := logger.Get()
 	userID := c.Param("user_id")
@@ -75,7 +322,7 @@ func (h *SubscriptionReadHandler) GetByUserID(c *gin.Context) {
 	subs, err := h.queryService.GetByUserID(ctx, userID)
 	if err != nil {
 		log.Errorf("Failed to get subscriptions for user_id=%s: %v", userID, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -84,53 +331,99 @@ func (h *SubscriptionReadHandler) GetByUserID(c *gin.Context) {
 }
 
 // SumPriceByFilter godoc
+// @Summary      Get total subscription price by filters
+// @Description  Calculates the total cost of subscriptions over [start_date, end_date] filtered by user_id and service_name.
+// @Description  mode=billed (default) counts every charge date of the subscription billing period inside the window, mode=prorated
+// @Description  charges each billing period by the share of it that overlaps the window, mode=starts_only sums prices of subscriptions started in the window
+// @Tags         subscriptions
+// @Produce      json
+// @Param        user_id          query     string  false  "User ID"
+// @Param        service_name     query     string  false  "Service Name, catalog aliases select the same service"
+// @Param        start_date       query     string  true   "Start Date (RFC3339 format)"
+// @Param        end_date         query     string  true   "End Date (RFC3339 format)"
+// @Param        mode             query     string  false  "Calculation mode" Enums(billed, prorated, starts_only) default(billed)
+// @Param        target_currency  query     string  false  "Currency of the result, each charge is converted at the rate of its billing date" Enums(RUB, USD, EUR) default(RUB)
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  model.Problem
+// @Failure      422  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/sum [get]
 func (h *SubscriptionReadHandler) SumPriceByFilter(c *gin.Context) {
 	log := logger.Get()
-	userID := c.Query("user_id")
-	serviceName := c.Query("service_name")
-	startDateStr := c.Query("start_date")
-	endDateStr := c.Query("end_date")
-
-	log.Infof("Handler: SumPriceByFilter() called | user_id=%s service_name=%s start=%s end=%s",
-		userID, serviceName, startDateStr, endDateStr)
+	log.Infof("Handler: SumPriceByFilter() called | query=%s", c.Request.URL.RawQuery)
 
 	ctx := c.Request.Context()
 
-	if startDateStr == "" || endDateStr == "" {
-		log.Warn("Missing required parameters: start_date or end_date")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required"})
+	query, err := parseCostQuery(c)
+	if err != nil {
+		log.Warnf("Invalid sum query: %v", err)
+		_ = c.Error(invalidRequest(err))
 		return
 	}
 
-	startDate, err := time.Parse(time.RFC3339, startDateStr)
+	sum, err := h.queryService.SumPriceByFilter(ctx, query)
 	if err != nil {
-		log.Warnf("Invalid start_date format: %s", startDateStr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+		log.Errorf("Failed to calculate sum for filter: %v", err)
+		_ = c.Error(err)
 		return
 	}
 
-	endDate, err := time.Parse(time.RFC3339, endDateStr)
+	log.Infof("SumPriceByFilter() result: %s %s", sum.Amount, sum.Currency)
+	c.JSON(http.StatusOK, gin.H{"total_price": sum.Amount, "currency": sum.Currency})
+}
+
+// Breakdown godoc
+// @Summary      Monthly spend breakdown
+// @Description  Returns one bucket per calendar month of [start_date, end_date] with the cost of matching subscriptions,
+// @Description  optionally grouped by service_name and/or user_id. Filters and mode have the same meaning as in /subscriptions/sum
+// @Tags         subscriptions
+// @Produce      json
+// @Param        user_id          query     string  false  "User ID"
+// @Param        service_name     query     string  false  "Service Name, catalog aliases select the same service"
+// @Param        start_date       query     string  true   "Start Date (RFC3339 format)"
+// @Param        end_date         query     string  true   "End Date (RFC3339 format)"
+// @Param        mode             query     string  false  "Calculation mode" Enums(billed, prorated, starts_only) default(billed)
+// @Param        target_currency  query     string  false  "Currency of the result, each charge is converted at the rate of its billing date" Enums(RUB, USD, EUR) default(RUB)
+// @Param        group_by         query     string  false  "Comma separated grouping keys: service_name, user_id"
+// @Success      200  {object}  model.BreakdownResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      422  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/breakdown [get]
+func (h *SubscriptionReadHandler) Breakdown(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: Breakdown() called | query=%s", c.Request.URL.RawQuery)
+
+	ctx := c.Request.Context()
+
+	query, err := parseCostQuery(c)
 	if err != nil {
-		log.Warnf("Invalid end_date format: %s", endDateStr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+		log.Warnf("Invalid breakdown query: %v", err)
+		_ = c.Error(invalidRequest(err))
 		return
 	}
 
-	var userIDPtr, serviceNamePtr *string
-	if userID != "" {
-		userIDPtr = &userID
-	}
-	if serviceName != "" {
-		serviceNamePtr = &serviceName
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		for _, key := range strings.Split(groupBy, ",") {
+			key = strings.TrimSpace(key)
+			if key != model.GroupByServiceName && key != model.GroupByUserID {
+				log.Warnf("Invalid group_by: %s", groupBy)
+				_ = c.Error(domainerr.Validation("invalid_group_by", "invalid group_by: "+key))
+				return
+			}
+			if !slices.Contains(query.GroupBy, key) {
+				query.GroupBy = append(query.GroupBy, key)
+			}
+		}
 	}
 
-	sum, err := h.queryService.SumPriceByFilter(ctx, userIDPtr, serviceNamePtr, startDate, endDate)
+	res, err := h.queryService.Breakdown(ctx, query)
 	if err != nil {
-		log.Errorf("Failed to calculate sum for filter: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		log.Errorf("Failed to calculate breakdown: %v", err)
+		_ = c.Error(err)
 		return
 	}
 
-	log.Infof("SumPriceByFilter() result: %.2f", sum)
-	c.JSON(http.StatusOK, gin.H{"total_price": sum})
-}
\ No newline at end of file
+	log.Infof("Breakdown() result: buckets=%d total=%s", len(res.Buckets), res.Total)
+	c.JSON(http.StatusOK, res)
+}
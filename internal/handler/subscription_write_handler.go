@@ -1,33 +1,77 @@
 package handler
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
 	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/patch"
 	"github.com/winnamu6/go-subscription-service/internal/service"
 )
 
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	idempotencyKeyMaxLen = 255
+
+	createSubscriptionScope = "subscriptions.create"
+
+	importMaxBodySize = 10 << 20
+)
+
+var errImportTooLarge = domainerr.Validation("import_too_large", "import file must not exceed 10 MiB")
+
+var importFormats = map[string]string{
+	"text/csv":             model.ImportFormatCSV,
+	"application/x-ndjson": model.ImportFormatNDJSON,
+	"application/jsonl":    model.ImportFormatNDJSON,
+}
+
+var errUnsupportedPatchType = domainerr.UnsupportedMediaType("unsupported_patch_type",
+	"patch must be sent as "+patch.MergePatchContentType+" or "+patch.JSONPatchContentType)
+
 type SubscriptionWriteHandler struct {
-	commandService service.SubscriptionCommandService
+	commandService     service.SubscriptionCommandService
+	importService      service.SubscriptionImportService
+	idempotencyService service.IdempotencyService
+	deletedRetention   time.Duration
 }
 
-func NewSubscriptionWriteHandler(commandService service.SubscriptionCommandService) *SubscriptionWriteHandler {
-	return &SubscriptionWriteHandler{commandService: commandService}
+func NewSubscriptionWriteHandler(
+	commandService service.SubscriptionCommandService,
+	importService service.SubscriptionImportService,
+	idempotencyService service.IdempotencyService,
+	deletedRetention time.Duration,
+) *SubscriptionWriteHandler {
+	return &SubscriptionWriteHandler{
+		commandService:     commandService,
+		importService:      importService,
+		idempotencyService: idempotencyService,
+		deletedRetention:   deletedRetention,
+	}
 }
 
 // Create godoc
 // @Summary      Create a new subscription
-// @Description  Creates a new subscription record
+// @Description  Creates a new subscription record. With an Idempotency-Key header a retried request returns the original response instead of creating a duplicate
 // @Tags         subscriptions
 // @Accept       json
 // @Produce      json
-// @Param        subscription  body      model.CreateSubscriptionRequest  true  "Subscription Data"
-// @Success      201  {object}  model.Subscription
-// @Failure      400  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Param        Idempotency-Key  header    string                           false  "Unique key of the request (up to 255 characters)"
+// @Param        subscription     body      model.CreateSubscriptionRequest  true   "Subscription Data"
+// @Success      201  {object}  model.SubscriptionResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      422  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
 // @Router       /subscriptions [post]
 func (h *SubscriptionWriteHandler) Create(c *gin.Context) {
 	log := logger.Get()
@@ -38,33 +82,78 @@ func (h *SubscriptionWriteHandler) Create(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Warnf("Invalid create request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(invalidRequest(err))
 		return
 	}
 
-	sub, err := h.commandService.Create(ctx, &req)
+	create := func(ctx context.Context) (int, []byte, error) {
+		sub, err := h.commandService.Create(ctx, &req)
+		if err != nil {
+			return 0, nil, err
+		}
+		body, err := json.Marshal(sub)
+		if err != nil {
+			return 0, nil, err
+		}
+		log.Infof("Subscription created successfully with ID %d", sub.ID)
+		return http.StatusCreated, body, nil
+	}
+
+	key := c.GetHeader(idempotencyKeyHeader)
+	if key == "" {
+		status, body, err := create(ctx)
+		if err != nil {
+			log.Errorf("Failed to create subscription: %v", err)
+			_ = c.Error(err)
+			return
+		}
+		c.Data(status, "application/json; charset=utf-8", body)
+		return
+	}
+
+	if len(key) > idempotencyKeyMaxLen {
+		log.Warnf("Idempotency key too long: %d characters", len(key))
+		_ = c.Error(domainerr.Validation("idempotency_key_too_long", "Idempotency-Key is too long"))
+		return
+	}
+
+	fingerprint, err := service.RequestFingerprint(&req)
 	if err != nil {
-		log.Errorf("Failed to create subscription: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		log.Errorf("Failed to fingerprint create request: %v", err)
+		_ = c.Error(err)
 		return
 	}
 
-	log.Infof("Subscription created successfully with ID %d", sub.ID)
-	c.JSON(http.StatusCreated, sub)
+	// the key, the subscription and the stored response are committed together
+	record, replayed, err := h.idempotencyService.Do(ctx, createSubscriptionScope, key, fingerprint, create)
+	if err != nil {
+		log.Errorf("Failed to create subscription with idempotency key %s: %v", key, err)
+		_ = c.Error(err)
+		return
+	}
+	if replayed {
+		log.Infof("Replaying response for idempotency key %s", key)
+		c.Header("Idempotent-Replayed", "true")
+	}
+	c.Data(record.StatusCode, "application/json; charset=utf-8", record.ResponseBody)
 }
 
 // Update godoc
 // @Summary      Update a subscription
-// @Description  Updates an existing subscription by ID
+// @Description  Replaces all mutable fields of a subscription. Omitted optional fields are reset to their defaults; an omitted end_date makes the subscription open-ended
 // @Tags         subscriptions
 // @Accept       json
 // @Produce      json
-// @Param        id            path      int                              true  "Subscription ID"
-// @Param        subscription  body      model.UpdateSubscriptionRequest  true  "Updated Subscription Data"
-// @Success      200  {object}  model.Subscription
-// @Failure      400  {object}  map[string]string
-// @Failure      404  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Param        id            path      int                              true   "Subscription ID"
+// @Param        If-Match      header    string                           false  "ETag from a previous read"
+// @Param        subscription  body      model.UpdateSubscriptionRequest  true   "Updated Subscription Data"
+// @Success      200  {object}  model.SubscriptionResponse
+// @Header       200  {string}  ETag  "Version of the updated subscription"
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      412  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
 // @Router       /subscriptions/{id} [put]
 func (h *SubscriptionWriteHandler) Update(c *gin.Context) {
 	log := logger.Get()
@@ -75,37 +164,203 @@ func (h *SubscriptionWriteHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
 		log.Warnf("Invalid subscription ID: %s", idParam)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	ifMatch, err := parseIfMatch(c)
+	if err != nil {
+		log.Warnf("Invalid If-Match for ID=%d: %s", id, c.GetHeader("If-Match"))
+		_ = c.Error(invalidRequest(err))
 		return
 	}
 
 	var req model.UpdateSubscriptionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Warnf("Invalid update request for ID=%d: %v", id, err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(invalidRequest(err))
 		return
 	}
 
-	sub, err := h.commandService.Update(ctx, uint(id), &req)
+	sub, err := h.commandService.Update(ctx, uint(id), &req, ifMatch)
 	if err != nil {
 		log.Errorf("Failed to update subscription ID=%d: %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	log.Infof("Subscription ID=%d updated successfully", sub.ID)
+	c.Header("ETag", etag(sub.Version))
+	c.JSON(http.StatusOK, sub)
+}
+
+// Import godoc
+// @Summary      Import subscriptions
+// @Description  Creates subscriptions from a CSV file (header row with service_name, price, currency, user_id, start_date, end_date, billing_period, billing_interval, trial_end_date, trial_price, service_id) or from JSON Lines with one CreateSubscriptionRequest per line. Each row is validated like POST /subscriptions; rows that duplicate an existing subscription (same user, service and start date) are skipped. In atomic mode nothing is created if any row fails
+// @Tags         subscriptions
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        format   query     string  false  "File format; detected from Content-Type by default"  Enums(csv, ndjson)
+// @Param        mode     query     string  false  "atomic (default) or partial"                           Enums(atomic, partial)
+// @Param        dry_run  query     bool    false  "Validate only, create nothing"
+// @Param        file     body      string  true   "File content"
+// @Success      200  {object}  model.SubscriptionImportResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/import [post]
+func (h *SubscriptionWriteHandler) Import(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: Import() called | content-type=%s query=%s", c.ContentType(), c.Request.URL.RawQuery)
+
+	var params model.ImportSubscriptionsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		log.Warnf("Invalid import params: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	format := params.Format
+	if format == "" {
+		format = importFormats[c.ContentType()]
+	}
+	if format == "" {
+		log.Warnf("Unsupported import content type: %s", c.ContentType())
+		_ = c.Error(model.ErrUnsupportedImportFormat)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, importMaxBodySize)
+	res, err := h.importService.Import(c.Request.Context(), body, model.ImportOptions{
+		Format: format,
+		Mode:   params.Mode,
+		DryRun: params.DryRun,
+	})
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			err = errImportTooLarge
+		}
+		log.Errorf("Failed to import subscriptions: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Import finished | total=%d created=%d skipped=%d failed=%d", res.Total, res.Created, res.Skipped, res.Failed)
+	c.JSON(http.StatusOK, res)
+}
+
+// Patch godoc
+// @Summary      Partially update a subscription
+// @Description  Applies a JSON Merge Patch (application/merge-patch+json, an explicit null clears a field) or a JSON Patch (application/json-patch+json) to the fields of UpdateSubscriptionRequest
+// @Tags         subscriptions
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id        path      int     true   "Subscription ID"
+// @Param        If-Match  header    string  false  "ETag from a previous read"
+// @Param        patch     body      object  true   "Merge patch object or JSON Patch operations"
+// @Success      200  {object}  model.SubscriptionResponse
+// @Header       200  {string}  ETag  "Version of the updated subscription"
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      412  {object}  model.Problem
+// @Failure      415  {object}  model.Problem
+// @Failure      422  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id} [patch]
+func (h *SubscriptionWriteHandler) Patch(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Patch() called for subscription ID=%s content-type=%s", idParam, c.ContentType())
+
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	var applyPatch func(doc, patch []byte) ([]byte, error)
+	switch c.ContentType() {
+	case patch.MergePatchContentType:
+		applyPatch = patch.MergePatch
+	case patch.JSONPatchContentType:
+		applyPatch = patch.JSONPatch
+	default:
+		log.Warnf("Unsupported patch content type for ID=%d: %s", id, c.ContentType())
+		_ = c.Error(errUnsupportedPatchType)
+		return
+	}
+
+	ifMatch, err := parseIfMatch(c)
+	if err != nil {
+		log.Warnf("Invalid If-Match for ID=%d: %s", id, c.GetHeader("If-Match"))
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		_ = c.Error(invalidRequest(io.EOF))
+		return
+	}
+
+	sub, err := h.commandService.Patch(ctx, uint(id), func(req *model.UpdateSubscriptionRequest) error {
+		return patchRequest(req, body, applyPatch)
+	}, ifMatch)
+	if err != nil {
+		log.Errorf("Failed to patch subscription ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Subscription ID=%d patched successfully", sub.ID)
+	c.Header("ETag", etag(sub.Version))
 	c.JSON(http.StatusOK, sub)
 }
 
+// patchRequest applies body to req with applyPatch and decodes the result back
+// into req, rejecting unknown fields and values of the wrong type, and
+// validates it like a PUT body.
+func patchRequest(req *model.UpdateSubscriptionRequest, body []byte, applyPatch func(doc, patch []byte) ([]byte, error)) error {
+	doc, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	patched, err := applyPatch(doc, body)
+	if err != nil {
+		return err
+	}
+
+	*req = model.UpdateSubscriptionRequest{}
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(req); err != nil {
+		return invalidRequest(err)
+	}
+	return RequestValidator{}.ValidateStruct(req)
+}
+
 // Delete godoc
 // @Summary      Delete a subscription
 // @Description  Deletes a subscription by its ID
 // @Tags         subscriptions
 // @Produce      json
-// @Param        id   path      int  true  "Subscription ID"
+// @Param        id        path      int     true   "Subscription ID"
+// @Param        If-Match  header    string  false  "ETag from a previous read"
 // @Success      204  {string}  string  "No Content"
-// @Failure      400  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      412  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
 // @Router       /subscriptions/{id} [delete]
 func (h *SubscriptionWriteHandler) Delete(c *gin.Context) {
 	log := logger.Get()
@@ -116,16 +371,243 @@ func (h *SubscriptionWriteHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
 		log.Warnf("Invalid subscription ID: %s", idParam)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	ifMatch, err := parseIfMatch(c)
+	if err != nil {
+		log.Warnf("Invalid If-Match for ID=%d: %s", id, c.GetHeader("If-Match"))
+		_ = c.Error(invalidRequest(err))
 		return
 	}
 
-	if err := h.commandService.Delete(ctx, uint(id)); err != nil {
+	if err := h.commandService.Delete(ctx, uint(id), ifMatch); err != nil {
 		log.Errorf("Failed to delete subscription ID=%d: %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	log.Infof("Subscription ID=%d deleted successfully", id)
 	c.Status(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// Restore godoc
+// @Summary      Restore a deleted subscription
+// @Description  Undoes a soft delete of a subscription
+// @Tags         subscriptions
+// @Produce      json
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {object}  model.SubscriptionResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/restore [post]
+func (h *SubscriptionWriteHandler) Restore(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Restore() called for subscription ID=%s", idParam)
+
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	sub, err := h.commandService.Restore(ctx, uint(id))
+	if err != nil {
+		log.Errorf("Failed to restore subscription ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Subscription ID=%d restored successfully", id)
+	c.Header("ETag", etag(sub.Version))
+	c.JSON(http.StatusOK, sub)
+}
+
+// Pause godoc
+// @Summary      Pause a subscription
+// @Description  Stops billing from now on until resume_at, or until the subscription is resumed when resume_at is omitted. The body is optional
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                             true   "Subscription ID"
+// @Param        If-Match  header    string                          false  "ETag from a previous read"
+// @Param        input     body      model.PauseSubscriptionRequest  false  "Pause parameters"
+// @Success      200  {object}  model.SubscriptionResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      412  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/pause [post]
+func (h *SubscriptionWriteHandler) Pause(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Pause() called for subscription ID=%s", idParam)
+
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	ifMatch, err := parseIfMatch(c)
+	if err != nil {
+		log.Warnf("Invalid If-Match for ID=%d: %s", id, c.GetHeader("If-Match"))
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	var req model.PauseSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Warnf("Invalid pause request for ID=%d: %v", id, err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	sub, err := h.commandService.Pause(ctx, uint(id), &req, ifMatch)
+	if err != nil {
+		log.Errorf("Failed to pause subscription ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Subscription ID=%d paused successfully", id)
+	c.Header("ETag", etag(sub.Version))
+	c.JSON(http.StatusOK, sub)
+}
+
+// Resume godoc
+// @Summary      Resume a paused subscription
+// @Description  Ends the current pause now; billing continues from today
+// @Tags         subscriptions
+// @Produce      json
+// @Param        id        path      int     true   "Subscription ID"
+// @Param        If-Match  header    string  false  "ETag from a previous read"
+// @Success      200  {object}  model.SubscriptionResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      412  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/resume [post]
+func (h *SubscriptionWriteHandler) Resume(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Resume() called for subscription ID=%s", idParam)
+
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	ifMatch, err := parseIfMatch(c)
+	if err != nil {
+		log.Warnf("Invalid If-Match for ID=%d: %s", id, c.GetHeader("If-Match"))
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	sub, err := h.commandService.Resume(ctx, uint(id), ifMatch)
+	if err != nil {
+		log.Errorf("Failed to resume subscription ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Subscription ID=%d resumed successfully", id)
+	c.Header("ETag", etag(sub.Version))
+	c.JSON(http.StatusOK, sub)
+}
+
+// Cancel godoc
+// @Summary      Cancel a subscription
+// @Description  Ends the subscription now (mode=immediate) or at the end of its current billing period (mode=at_period_end)
+// @Description  and records the reason. An end date that is already earlier is kept. Clearing end_date with PUT or PATCH undoes the cancellation
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                              true   "Subscription ID"
+// @Param        If-Match  header    string                           false  "ETag from a previous read"
+// @Param        input     body      model.CancelSubscriptionRequest  true   "Cancellation"
+// @Success      200  {object}  model.SubscriptionResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      409  {object}  model.Problem
+// @Failure      412  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /subscriptions/{id}/cancel [post]
+func (h *SubscriptionWriteHandler) Cancel(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Cancel() called for subscription ID=%s", idParam)
+
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid subscription ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	ifMatch, err := parseIfMatch(c)
+	if err != nil {
+		log.Warnf("Invalid If-Match for ID=%d: %s", id, c.GetHeader("If-Match"))
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	var req model.CancelSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("Invalid cancel request for ID=%d: %v", id, err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	sub, err := h.commandService.Cancel(ctx, uint(id), &req, ifMatch)
+	if err != nil {
+		log.Errorf("Failed to cancel subscription ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Subscription ID=%d canceled successfully", id)
+	c.Header("ETag", etag(sub.Version))
+	c.JSON(http.StatusOK, sub)
+}
+
+// PurgeDeleted godoc
+// @Summary      Purge deleted subscriptions
+// @Description  Permanently removes subscriptions that were soft-deleted longer ago than the retention period (DELETED_RETENTION)
+// @Tags         subscriptions
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Success      200  {object}  model.PurgeDeletedResponse
+// @Failure      401  {object}  model.Problem
+// @Failure      403  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/subscriptions/purge [post]
+func (h *SubscriptionWriteHandler) PurgeDeleted(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: PurgeDeleted() called | retention=%s", h.deletedRetention)
+
+	before := time.Now().Add(-h.deletedRetention)
+	purged, err := h.commandService.PurgeDeleted(c.Request.Context(), before)
+	if err != nil {
+		log.Errorf("Failed to purge deleted subscriptions: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Purged %d deleted subscriptions", purged)
+	c.JSON(http.StatusOK, model.PurgeDeletedResponse{Purged: purged, DeletedBefore: before})
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/patch"
+)
+
+func TestPatchRequest(t *testing.T) {
+	UseRequestFieldNames()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	base := func() model.UpdateSubscriptionRequest {
+		e := end
+		return model.UpdateSubscriptionRequest{
+			ServiceName:   "Netflix",
+			Price:         19999,
+			Currency:      model.CurrencyRUB,
+			StartDate:     start,
+			EndDate:       &e,
+			BillingPeriod: model.BillingPeriodMonthly,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		apply     func(doc, patch []byte) ([]byte, error)
+		body      string
+		check     func(t *testing.T, req *model.UpdateSubscriptionRequest)
+		wantCode  string
+		wantField string
+	}{
+		{
+			name:  "merge sets a field",
+			apply: patch.MergePatch,
+			body:  `{"price":"249.90"}`,
+			check: func(t *testing.T, req *model.UpdateSubscriptionRequest) {
+				if req.Price != 24990 || req.ServiceName != "Netflix" || req.EndDate == nil {
+					t.Errorf("patched request = %+v", req)
+				}
+			},
+		},
+		{
+			name:  "merge null clears the end date",
+			apply: patch.MergePatch,
+			body:  `{"end_date":null}`,
+			check: func(t *testing.T, req *model.UpdateSubscriptionRequest) {
+				if req.EndDate != nil {
+					t.Errorf("end_date = %v, want nil", req.EndDate)
+				}
+			},
+		},
+		{
+			name:  "merge null resets an optional field",
+			apply: patch.MergePatch,
+			body:  `{"currency":null}`,
+			check: func(t *testing.T, req *model.UpdateSubscriptionRequest) {
+				if req.Currency != "" {
+					t.Errorf("currency = %q, want empty", req.Currency)
+				}
+			},
+		},
+		{
+			name:      "merge null on a required field",
+			apply:     patch.MergePatch,
+			body:      `{"service_name":null}`,
+			wantCode:  "validation_failed",
+			wantField: "service_name",
+		},
+		{
+			name:      "unknown field",
+			apply:     patch.MergePatch,
+			body:      `{"colour":"red"}`,
+			wantCode:  "validation_failed",
+			wantField: "colour",
+		},
+		{
+			name:      "wrong type",
+			apply:     patch.MergePatch,
+			body:      `{"billing_interval":"two"}`,
+			wantCode:  "validation_failed",
+			wantField: "billing_interval",
+		},
+		{
+			name:     "invalid time",
+			apply:    patch.MergePatch,
+			body:     `{"end_date":"tomorrow"}`,
+			wantCode: "invalid_request",
+		},
+		{
+			name:     "malformed merge patch",
+			apply:    patch.MergePatch,
+			body:     `{"price":`,
+			wantCode: "invalid_patch",
+		},
+		{
+			name:  "json patch replaces with null",
+			apply: patch.JSONPatch,
+			body:  `[{"op":"replace","path":"/end_date","value":null}]`,
+			check: func(t *testing.T, req *model.UpdateSubscriptionRequest) {
+				if req.EndDate != nil {
+					t.Errorf("end_date = %v, want nil", req.EndDate)
+				}
+			},
+		},
+		{
+			name:  "json patch removes a field",
+			apply: patch.JSONPatch,
+			body:  `[{"op":"test","path":"/currency","value":"RUB"},{"op":"remove","path":"/currency"}]`,
+			check: func(t *testing.T, req *model.UpdateSubscriptionRequest) {
+				if req.Currency != "" {
+					t.Errorf("currency = %q, want empty", req.Currency)
+				}
+			},
+		},
+		{
+			name:     "json patch test fails",
+			apply:    patch.JSONPatch,
+			body:     `[{"op":"test","path":"/price","value":1}]`,
+			wantCode: "patch_not_applicable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base()
+			err := patchRequest(&req, []byte(tt.body), tt.apply)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("patchRequest unexpected error: %v", err)
+				}
+				tt.check(t, &req)
+				return
+			}
+
+			de, ok := domainerr.As(err)
+			if !ok {
+				t.Fatalf("patchRequest error = %v, want a domain error", err)
+			}
+			if de.Code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", de.Code, tt.wantCode)
+			}
+			if tt.wantField != "" && (len(de.Fields) != 1 || de.Fields[0].Field != tt.wantField) {
+				t.Errorf("error fields = %+v, want %s", de.Fields, tt.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/service"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// Create godoc
+// @Summary      Register a webhook endpoint
+// @Description  Registers a URL that receives the given subscription events as signed POST requests. See the README for the signature scheme
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string                      true  "Admin token"
+// @Param        webhook        body      model.CreateWebhookRequest  true  "Webhook endpoint"
+// @Success      201  {object}  model.WebhookEndpoint
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	log := logger.Get()
+	log.Infof("Handler: Webhook Create() called from %s", c.ClientIP())
+
+	var req model.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("Invalid webhook request: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(c.Request.Context(), &req)
+	if err != nil {
+		log.Errorf("Failed to create webhook endpoint: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Webhook endpoint created with ID=%d", endpoint.ID)
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+// List godoc
+// @Summary      List webhook endpoints
+// @Tags         webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Success      200  {array}   model.WebhookEndpoint
+// @Failure      401  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	log := logger.Get()
+	log.Info("Handler: Webhook List() called")
+
+	endpoints, err := h.webhookService.ListEndpoints(c.Request.Context())
+	if err != nil {
+		log.Errorf("Failed to list webhook endpoints: %v", err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d webhook endpoints", len(endpoints))
+	c.JSON(http.StatusOK, endpoints)
+}
+
+// Get godoc
+// @Summary      Get a webhook endpoint
+// @Tags         webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Param        id             path      int     true  "Webhook endpoint ID"
+// @Success      200  {object}  model.WebhookEndpoint
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/webhooks/{id} [get]
+func (h *WebhookHandler) Get(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Webhook Get() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid webhook ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	endpoint, err := h.webhookService.GetEndpoint(c.Request.Context(), uint(id))
+	if err != nil {
+		log.Errorf("Failed to get webhook endpoint ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// Delete godoc
+// @Summary      Delete a webhook endpoint
+// @Description  Stops sending events to the endpoint. Its pending deliveries fail; the delivery log is kept
+// @Tags         webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Param        id             path      int     true  "Webhook endpoint ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Webhook Delete() called for ID=%s", idParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid webhook ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.Request.Context(), uint(id)); err != nil {
+		log.Errorf("Failed to delete webhook endpoint ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Webhook endpoint ID=%d deleted", id)
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      Delivery log of a webhook endpoint
+// @Description  Returns deliveries to the endpoint, newest first, with the outcome of their last attempt
+// @Tags         webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true   "Admin token"
+// @Param        id             path      int     true   "Webhook endpoint ID"
+// @Param        status         query     string  false  "Delivery status" Enums(pending, succeeded, failed)
+// @Param        event_type     query     string  false  "Event type"
+// @Param        limit          query     int     false  "Page size (1-100, default 20)"
+// @Param        offset         query     int     false  "Offset"
+// @Success      200  {object}  model.WebhookDeliveryListResponse
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	log := logger.Get()
+	idParam := c.Param("id")
+	log.Infof("Handler: Webhook ListDeliveries() called for ID=%s | query=%s", idParam, c.Request.URL.RawQuery)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid webhook ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	var params model.ListWebhookDeliveriesParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		log.Warnf("Invalid deliveries query: %v", err)
+		_ = c.Error(invalidRequest(err))
+		return
+	}
+
+	filter := model.WebhookDeliveryFilter{
+		EndpointID: uint(id),
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	}
+	if params.Status != "" {
+		filter.Status = &params.Status
+	}
+	if params.EventType != "" {
+		filter.EventType = &params.EventType
+	}
+
+	res, err := h.webhookService.ListDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		log.Errorf("Failed to list deliveries of webhook ID=%d: %v", id, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Retrieved %d deliveries of webhook ID=%d", len(res.Items), id)
+	c.JSON(http.StatusOK, res)
+}
+
+// Replay godoc
+// @Summary      Replay a webhook delivery
+// @Description  Queues the event of a delivery to be sent to its endpoint again. The copy is a new delivery with replay_of set
+// @Tags         webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Param        id             path      int     true  "Webhook endpoint ID"
+// @Param        delivery_id    path      int     true  "Delivery ID"
+// @Success      202  {object}  model.WebhookDelivery
+// @Failure      400  {object}  model.Problem
+// @Failure      401  {object}  model.Problem
+// @Failure      404  {object}  model.Problem
+// @Failure      500  {object}  model.Problem
+// @Router       /admin/webhooks/{id}/deliveries/{delivery_id}/replay [post]
+func (h *WebhookHandler) Replay(c *gin.Context) {
+	log := logger.Get()
+	idParam, deliveryParam := c.Param("id"), c.Param("delivery_id")
+	log.Infof("Handler: Webhook Replay() called for ID=%s delivery=%s", idParam, deliveryParam)
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid webhook ID: %s", idParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+	deliveryID, err := strconv.ParseUint(deliveryParam, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid delivery ID: %s", deliveryParam)
+		_ = c.Error(errInvalidID)
+		return
+	}
+
+	delivery, err := h.webhookService.Replay(c.Request.Context(), uint(id), uint(deliveryID))
+	if err != nil {
+		log.Errorf("Failed to replay delivery ID=%d: %v", deliveryID, err)
+		_ = c.Error(err)
+		return
+	}
+
+	log.Infof("Delivery ID=%d queued as ID=%d", deliveryID, delivery.ID)
+	c.JSON(http.StatusAccepted, delivery)
+}
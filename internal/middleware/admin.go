@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/requestmeta"
+)
+
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminOnly rejects requests without a valid X-Admin-Token header and records
+// the admin as the actor of the others. When no token is configured the admin
+// API is disabled entirely.
+func AdminOnly(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.Get()
+
+		if token == "" {
+			log.Warnf("[AdminOnly] Admin API disabled, rejecting %s %s", c.Request.Method, c.Request.URL.Path)
+			_ = c.Error(domainerr.Forbidden("admin_api_disabled", "admin API is disabled"))
+			c.Abort()
+			return
+		}
+
+		provided := c.GetHeader(AdminTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			log.Warnf("[AdminOnly] Invalid admin token from %s for %s %s", c.ClientIP(), c.Request.Method, c.Request.URL.Path)
+			_ = c.Error(domainerr.Unauthorized("invalid_admin_token", "invalid admin token"))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(requestmeta.WithActor(c.Request.Context(), requestmeta.AdminActor))
+		c.Next()
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/requestmeta"
+)
+
+// problemTypeBase prefixes the error code to form the problem type URI.
+const problemTypeBase = "/problems/"
+
+var kindStatus = map[domainerr.Kind]int{
+	domainerr.KindValidation:           http.StatusBadRequest,
+	domainerr.KindUnauthorized:         http.StatusUnauthorized,
+	domainerr.KindForbidden:            http.StatusForbidden,
+	domainerr.KindNotFound:             http.StatusNotFound,
+	domainerr.KindConflict:             http.StatusConflict,
+	domainerr.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	domainerr.KindUnprocessable:        http.StatusUnprocessableEntity,
+	domainerr.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+}
+
+// ErrorHandler renders the last error added with c.Error as an RFC 7807
+// application/problem+json response. Domain errors get the status of their
+// kind; anything else becomes a 500 without leaking the original message.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		log := logger.Get()
+		err := c.Errors.Last().Err
+
+		problem := model.Problem{
+			Status:    http.StatusInternalServerError,
+			Code:      domainerr.CodeInternal,
+			Detail:    "internal server error",
+			Instance:  c.Request.URL.Path,
+			RequestID: requestmeta.From(c.Request.Context()).RequestID,
+		}
+
+		if de, ok := domainerr.As(err); ok {
+			if status, known := kindStatus[de.Kind]; known {
+				problem.Status = status
+				problem.Code = de.Code
+				problem.Detail = err.Error()
+				problem.Errors = de.Fields
+			}
+		}
+		problem.Type = problemTypeBase + problem.Code
+		problem.Title = http.StatusText(problem.Status)
+
+		if problem.Status == http.StatusInternalServerError {
+			log.Errorf("[ErrorHandler] Internal error | %s %s err=%v", c.Request.Method, c.Request.URL.Path, err)
+		} else {
+			log.Warnf("[ErrorHandler] Request failed | %s %s status=%d code=%s err=%v",
+				c.Request.Method, c.Request.URL.Path, problem.Status, problem.Code, err)
+		}
+
+		body, _ := json.Marshal(problem)
+		c.Data(problem.Status, model.ProblemContentType, body)
+	}
+}
+
+// NotFound answers unknown routes with a problem response.
+func NotFound() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = c.Error(domainerr.NotFound("route_not_found", "route not found"))
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/requestmeta"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	// ActorHeader lets clients say on whose behalf they act. It is not
+	// verified and only recorded as a hint next to the authenticated actor.
+	ActorHeader = "X-Actor"
+
+	maxActorHintLen = 255
+)
+
+// RequestMeta attaches the request ID, actor and client IP to the request
+// context. The request ID is taken from X-Request-ID when the client sends
+// one and echoed back in the response. The actor starts out anonymous and is
+// replaced by the middleware that authenticates the request.
+func RequestMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewString()
+		}
+
+		hint := c.GetHeader(ActorHeader)
+		if len(hint) > maxActorHintLen {
+			hint = hint[:maxActorHintLen]
+		}
+		hint = strings.ToValidUTF8(hint, "")
+
+		ctx := requestmeta.With(c.Request.Context(), requestmeta.Meta{
+			RequestID: requestID,
+			Actor:     requestmeta.AnonymousActor,
+			ActorHint: hint,
+			SourceIP:  c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
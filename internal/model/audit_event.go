@@ -0,0 +1,76 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditEntitySubscription = "subscription"
+
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+	AuditActionPause   = "pause"
+	AuditActionResume  = "resume"
+	AuditActionCancel  = "cancel"
+)
+
+// AuditEvent records a single change of an entity. Changes holds the diff as
+// {"field": {"from": old, "to": new}}; Before/After are full snapshots. Actor
+// is derived from the credentials of the request (see requestmeta).
+type AuditEvent struct {
+	ID         uint            `gorm:"primaryKey" json:"id"`
+	EntityType string          `gorm:"type:varchar(64);not null;index:idx_audit_events_entity" json:"entity_type"`
+	EntityID   uint            `gorm:"not null;index:idx_audit_events_entity" json:"entity_id"`
+	Action     string          `gorm:"type:varchar(32);not null;index" json:"action"`
+	Actor      string          `gorm:"type:varchar(255);not null;index" json:"actor"`
+	ActorHint  string          `gorm:"type:varchar(255)" json:"actor_hint,omitempty"` // непроверенный X-Actor
+	RequestID  string          `gorm:"type:varchar(128);index" json:"request_id,omitempty"`
+	SourceIP   string          `gorm:"type:varchar(64)" json:"source_ip,omitempty"`
+	Before     json.RawMessage `gorm:"type:jsonb" json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `gorm:"type:jsonb" json:"after,omitempty" swaggertype:"object"`
+	Changes    json.RawMessage `gorm:"type:jsonb" json:"changes,omitempty" swaggertype:"object"`
+	CreatedAt  time.Time       `gorm:"index" json:"created_at"`
+}
+
+type AuditFilter struct {
+	EntityType *string
+	EntityID   *uint
+	Action     *string
+	Actor      *string
+	RequestID  *string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditEventListResponse struct {
+	Items  []AuditEvent `json:"items"`
+	Total  int64        `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// SubscriptionHistoryEntry is the public view of an AuditEvent of a
+// subscription. Request metadata (X-Actor hint, request id, source IP) is only
+// available through the admin audit log.
+type SubscriptionHistoryEntry struct {
+	ID        uint            `json:"id"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	Changes   json.RawMessage `json:"changes,omitempty" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type SubscriptionHistoryResponse struct {
+	Items  []SubscriptionHistoryEntry `json:"items"`
+	Total  int64                      `json:"total"`
+	Limit  int                        `json:"limit"`
+	Offset int                        `json:"offset"`
+}
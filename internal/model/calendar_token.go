@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+// ErrCalendarNotFound is returned both for unknown users and for wrong tokens,
+// so that a feed URL does not reveal whether a user exists.
+var ErrCalendarNotFound = domainerr.NotFound("calendar_not_found", "calendar not found")
+
+// CalendarToken is the secret that gives access to the calendar feed of a
+// user. Only its hash is stored; issuing a new token revokes the old one.
+type CalendarToken struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TokenHash string    `gorm:"type:char(64);not null"` // sha256 токена
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+type CalendarTokenResponse struct {
+	UserID uuid.UUID `json:"user_id"`
+	Token  string    `json:"token"`
+	// Путь ленты с токеном, который можно добавить в календарь по подписке
+	FeedPath string `json:"feed_path" example:"/users/550e8400-e29b-41d4-a716-446655440000/calendar.ics?token=..."`
+}
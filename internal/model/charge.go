@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Charge is a materialized billing of a subscription for one period. The
+// unique (subscription_id, period_start) pair makes renewal idempotent.
+type Charge struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint      `gorm:"not null;uniqueIndex:idx_charges_subscription_period" json:"subscription_id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	ServiceName    string    `gorm:"type:varchar(255);not null" json:"service_name"`
+	PeriodStart    time.Time `gorm:"not null;uniqueIndex:idx_charges_subscription_period" json:"period_start"`
+	PeriodEnd      time.Time `gorm:"not null" json:"period_end"`
+	Amount         Amount    `gorm:"column:amount_minor;type:bigint;not null" json:"amount" swaggertype:"number"`
+	Currency       string    `gorm:"type:char(3);not null" json:"currency"`
+	CreatedAt      time.Time `json:"created_at"`
+}
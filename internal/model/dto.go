@@ -7,27 +7,254 @@ import (
 )
 
 type CreateSubscriptionRequest struct {
-	ServiceName string    `json:"service_name" binding:"required,min=2,max=255"`
-	Price       float64   `json:"price" binding:"required,gt=0"`
-	UserID      uuid.UUID `json:"user_id" binding:"required"`
-	StartDate   time.Time `json:"start_date" binding:"required"`
-	EndDate     *time.Time `json:"end_date,omitempty"`
+	ServiceName     string     `json:"service_name" binding:"required_without=ServiceID,omitempty,min=2,max=255"`
+	Price           Amount     `json:"price" binding:"required_without=ServiceID,omitempty,gt=0" swaggertype:"number"`
+	Currency        string     `json:"currency,omitempty" binding:"omitempty,oneof=RUB USD EUR"`
+	UserID          uuid.UUID  `json:"user_id" binding:"required"`
+	StartDate       time.Time  `json:"start_date" binding:"required"`
+	EndDate         *time.Time `json:"end_date,omitempty"`
+	BillingPeriod   string     `json:"billing_period,omitempty" binding:"omitempty,oneof=weekly monthly quarterly yearly custom" example:"monthly"`
+	BillingInterval int        `json:"billing_interval,omitempty" binding:"omitempty,min=1,max=3660" example:"1"`
+	// Дата окончания пробного периода; регулярные списания начинаются с неё
+	TrialEndDate *time.Time `json:"trial_end_date,omitempty"`
+	// Цена всего пробного периода в валюте подписки; по умолчанию 0 — бесплатно
+	TrialPrice Amount `json:"trial_price,omitempty" binding:"gte=0" swaggertype:"number"`
+	// Запись каталога сервисов. service_name берётся из каталога, а price, currency и billing_period,
+	// если не заданы, — из значений сервиса по умолчанию. Без service_id service_name сопоставляется
+	// с названиями и синонимами каталога
+	ServiceID *uint `json:"service_id,omitempty" example:"1"`
 }
 
+// UpdateSubscriptionRequest полностью заменяет изменяемые поля подписки (PUT).
+// Опущенные необязательные поля получают значения по умолчанию, отсутствующий
+// end_date делает подписку бессрочной. Владелец подписки (user_id) не меняется.
 type UpdateSubscriptionRequest struct {
-	ServiceName string     `json:"service_name" binding:"omitempty,min=2,max=255"`
-	Price       *float64   `json:"price,omitempty" binding:"omitempty,gt=0"`
-	StartDate   *time.Time `json:"start_date,omitempty"`
-	EndDate     *time.Time `json:"end_date,omitempty"`
+	ServiceName     string     `json:"service_name" binding:"required_without=ServiceID,omitempty,min=2,max=255"`
+	Price           Amount     `json:"price" binding:"required_without=ServiceID,omitempty,gt=0" swaggertype:"number"`
+	Currency        string     `json:"currency,omitempty" binding:"omitempty,oneof=RUB USD EUR"`
+	StartDate       time.Time  `json:"start_date" binding:"required"`
+	EndDate         *time.Time `json:"end_date,omitempty"`
+	BillingPeriod   string     `json:"billing_period,omitempty" binding:"omitempty,oneof=weekly monthly quarterly yearly custom" example:"monthly"`
+	BillingInterval int        `json:"billing_interval,omitempty" binding:"omitempty,min=1,max=3660" example:"1"`
+	// Дата окончания пробного периода; регулярные списания начинаются с неё
+	TrialEndDate *time.Time `json:"trial_end_date,omitempty"`
+	// Цена всего пробного периода в валюте подписки; по умолчанию 0 — бесплатно
+	TrialPrice Amount `json:"trial_price,omitempty" binding:"gte=0" swaggertype:"number"`
+	// Дата, с которой действует новая цена. Может быть в прошлом или в будущем; по умолчанию — текущий момент
+	PriceEffectiveFrom *time.Time `json:"price_effective_from,omitempty"`
+	// Запись каталога сервисов, как при создании
+	ServiceID *uint `json:"service_id,omitempty" example:"1"`
 }
 
 type SubscriptionResponse struct {
-	ID          uint       `json:"id"`
-	ServiceName string     `json:"service_name"`
-	Price       float64    `json:"price"`
-	UserID      uuid.UUID  `json:"user_id"`
-	StartDate   time.Time  `json:"start_date"`
-	EndDate     *time.Time `json:"end_date,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID              uint       `json:"id"`
+	ServiceName     string     `json:"service_name"`
+	ServiceID       *uint      `json:"service_id,omitempty"`
+	Price           Amount     `json:"price" swaggertype:"number"`
+	Currency        string     `json:"currency"`
+	UserID          uuid.UUID  `json:"user_id"`
+	StartDate       time.Time  `json:"start_date"`
+	EndDate         *time.Time `json:"end_date,omitempty"`
+	BillingPeriod   string     `json:"billing_period"`
+	BillingInterval int        `json:"billing_interval"`
+	TrialEndDate    *time.Time `json:"trial_end_date,omitempty"`
+	TrialPrice      Amount     `json:"trial_price" swaggertype:"number"`
+	Version         int        `json:"version"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	// Вычисляется на момент запроса
+	Status string `json:"status" enums:"active,paused,ended,trial"`
+	// Последняя или запланированная пауза; действует ли она сейчас, видно по status
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+	ResumeAt *time.Time `json:"resume_at,omitempty"`
+	// Когда подписка отменена; end_date при этом — дата окончания
+	CanceledAt *time.Time `json:"canceled_at,omitempty"`
+}
+
+// PauseSubscriptionRequest приостанавливает подписку с текущего момента.
+// Тело запроса необязательно.
+type PauseSubscriptionRequest struct {
+	// Дата автоматического возобновления; без неё пауза длится до POST /subscriptions/{id}/resume
+	ResumeAt *time.Time `json:"resume_at,omitempty"`
+}
+
+// CancelSubscriptionRequest отменяет подписку: immediate завершает её сейчас,
+// at_period_end — в конце текущего расчётного периода.
+type CancelSubscriptionRequest struct {
+	Mode   string `json:"mode" binding:"required,oneof=immediate at_period_end" example:"at_period_end"`
+	Reason string `json:"reason" binding:"required,oneof=too_expensive not_using switched_service missing_features technical_issues other" example:"too_expensive"`
+	// Свободный комментарий пользователя
+	Comment string `json:"comment,omitempty" binding:"max=2000"`
+}
+
+type ListSubscriptionsParams struct {
+	ServiceName    string     `form:"service_name"`
+	UserID         string     `form:"user_id" binding:"omitempty,uuid"`
+	PriceMin       *Amount    `form:"price_min" binding:"omitempty,gte=0"`
+	PriceMax       *Amount    `form:"price_max" binding:"omitempty,gte=0"`
+	StartDateFrom  *time.Time `form:"start_date_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	StartDateTo    *time.Time `form:"start_date_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDateFrom    *time.Time `form:"end_date_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDateTo      *time.Time `form:"end_date_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	ActiveAt       *time.Time `form:"active_at" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort           string     `form:"sort"`
+	Limit          int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset         int        `form:"offset" binding:"omitempty,min=0"`
+	Cursor         string     `form:"cursor"`
+	IncludeDeleted bool       `form:"include_deleted"`
+	// Только подписки, которые сейчас в пробном периоде
+	InTrial bool `form:"in_trial"`
+	// Окончание пробного периода в интервале, например «пробные периоды, заканчивающиеся на этой неделе»
+	TrialEndDateFrom *time.Time `form:"trial_end_date_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	TrialEndDateTo   *time.Time `form:"trial_end_date_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Подписки, сопоставленные с записью каталога сервисов
+	ServiceID *uint `form:"service_id"`
+}
+
+// ExportSubscriptionsParams принимает те же фильтры и сортировку, что и список;
+// параметры пагинации игнорируются
+type ExportSubscriptionsParams struct {
+	ListSubscriptionsParams
+	Format  string `form:"format" binding:"required,oneof=csv xlsx ndjson"`
+	Columns string `form:"columns"` // список колонок через запятую
+	Locale  string `form:"locale" binding:"omitempty,oneof=en ru"`
+}
+
+type PurgeDeletedResponse struct {
+	Purged        int       `json:"purged"`
+	DeletedBefore time.Time `json:"deleted_before"`
+}
+
+type SubscriptionListResponse struct {
+	Items      []SubscriptionResponse `json:"items"`
+	Total      int64                  `json:"total"`
+	Limit      int                    `json:"limit"`
+	Offset     int                    `json:"offset"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+type BreakdownGroup struct {
+	ServiceName string     `json:"service_name,omitempty"`
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	Total       Amount     `json:"total" swaggertype:"number"`
+}
+
+type BreakdownBucket struct {
+	Month  string           `json:"month"`
+	Total  Amount           `json:"total" swaggertype:"number"`
+	Groups []BreakdownGroup `json:"groups,omitempty"`
+}
+
+type BreakdownResponse struct {
+	StartDate time.Time         `json:"start_date"`
+	EndDate   time.Time         `json:"end_date"`
+	Mode      SumMode           `json:"mode"`
+	GroupBy   []string          `json:"group_by,omitempty"`
+	Currency  string            `json:"currency"`
+	Total     Amount            `json:"total" swaggertype:"number"`
+	Buckets   []BreakdownBucket `json:"buckets"`
+}
+
+type ExchangeRateRequest struct {
+	FromCurrency  string `json:"from_currency" binding:"required,oneof=RUB USD EUR"`
+	ToCurrency    string `json:"to_currency" binding:"required,oneof=RUB USD EUR,nefield=FromCurrency"`
+	Rate          string `json:"rate" binding:"required,numeric"`
+	EffectiveDate string `json:"effective_date" binding:"required,datetime=2006-01-02" example:"2025-01-31"`
+}
+
+type ExchangeRateImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type ImportSubscriptionsParams struct {
+	// Формат файла; по умолчанию определяется по Content-Type
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+	Mode   string `form:"mode" binding:"omitempty,oneof=atomic partial" example:"atomic"`
+	DryRun bool   `form:"dry_run"`
+}
+
+type ListAuditEventsParams struct {
+	EntityType     string     `form:"entity_type"`
+	SubscriptionID *uint      `form:"subscription_id"`
+	Action         string     `form:"action" binding:"omitempty,oneof=create update delete restore purge pause resume cancel"`
+	Actor          string     `form:"actor"`
+	RequestID      string     `form:"request_id"`
+	From           *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To             *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit          int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset         int        `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ServiceRequest создаёт или полностью заменяет запись каталога сервисов.
+// Название и синонимы сравниваются без учёта регистра, лишних пробелов и «ё»
+// и не должны совпадать с названиями и синонимами других сервисов.
+type ServiceRequest struct {
+	Name     string   `json:"name" binding:"required,min=2,max=255" example:"Yandex Plus"`
+	Aliases  []string `json:"aliases,omitempty" binding:"max=50,dive,min=2,max=255"`
+	Category string   `json:"category,omitempty" binding:"max=64" example:"entertainment"`
+	Website  string   `json:"website,omitempty" binding:"omitempty,url,max=2048" example:"https://plus.yandex.ru"`
+	// Значения по умолчанию для новых подписок на сервис
+	DefaultPrice           Amount `json:"default_price,omitempty" binding:"gte=0" swaggertype:"number"`
+	DefaultCurrency        string `json:"default_currency,omitempty" binding:"omitempty,oneof=RUB USD EUR"`
+	DefaultBillingPeriod   string `json:"default_billing_period,omitempty" binding:"omitempty,oneof=weekly monthly quarterly yearly custom" example:"monthly"`
+	DefaultBillingInterval int    `json:"default_billing_interval,omitempty" binding:"omitempty,min=1,max=3660" example:"1"`
+}
+
+// MergeServiceRequest вливает запись каталога ServiceID в запись из пути:
+// её подписки переходят к этой записи, название и синонимы становятся
+// синонимами, а сама запись удаляется.
+type MergeServiceRequest struct {
+	ServiceID uint `json:"service_id" binding:"required,min=1" example:"2"`
+}
+
+type ChurnParams struct {
+	ServiceName string     `form:"service_name"`
+	From        *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To          *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type ChurnReasonCount struct {
+	Reason string `json:"reason" example:"too_expensive"`
+	Count  int64  `json:"count"`
+}
+
+type ChurnServiceStat struct {
+	ServiceName string             `json:"service_name"`
+	Total       int64              `json:"total"`
+	Reasons     []ChurnReasonCount `json:"reasons"`
+}
+
+// ChurnResponse — отмены за период по сервисам, причины — от частых к редким.
+type ChurnResponse struct {
+	From     *time.Time         `json:"from,omitempty"`
+	To       *time.Time         `json:"to,omitempty"`
+	Total    int64              `json:"total"`
+	Services []ChurnServiceStat `json:"services"`
+}
+
+type CreateWebhookRequest struct {
+	URL string `json:"url" binding:"required,url,max=2048" example:"https://example.com/hooks/subscriptions"`
+	// Ключ подписи; получатель проверяет им заголовок X-Webhook-Signature
+	Secret     string   `json:"secret" binding:"required,min=16,max=255"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,oneof=subscription.created subscription.updated subscription.deleted subscription.restored subscription.expired subscription.trial_converted subscription.canceled subscription.paused subscription.resumed"`
+}
+
+type ListWebhookDeliveriesParams struct {
+	Status    string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	EventType string `form:"event_type"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset    int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// UpdateNotificationPreferencesRequest полностью заменяет настройки
+// напоминаний пользователя (PUT).
+type UpdateNotificationPreferencesRequest struct {
+	Email string `json:"email" binding:"required,email,max=320" example:"user@example.com"`
+	// По умолчанию true
+	RenewalReminders *bool `json:"renewal_reminders,omitempty"`
+	// По умолчанию true
+	ExpirationReminders *bool `json:"expiration_reminders,omitempty"`
+	// За сколько дней напоминать; по умолчанию REMINDER_DAYS_AHEAD
+	DaysBefore *int `json:"days_before,omitempty" binding:"omitempty,min=1,max=60" example:"3"`
 }
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+var (
+	ErrRateNotFound        = domainerr.Unprocessable("exchange_rate_not_found", "exchange rate not found")
+	ErrInvalidExchangeRate = domainerr.Validation("invalid_exchange_rate", "invalid exchange rate")
+)
+
+type ExchangeRate struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	FromCurrency  string    `gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rates_pair_date" json:"from_currency"`
+	ToCurrency    string    `gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rates_pair_date" json:"to_currency"`
+	Rate          string    `gorm:"type:numeric(20,10);not null" json:"rate"` // сколько единиц ToCurrency за одну FromCurrency
+	EffectiveDate time.Time `gorm:"type:date;not null;uniqueIndex:idx_exchange_rates_pair_date" json:"effective_date"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+type SumMode string
+
+const (
+	SumModeBilled     SumMode = "billed"
+	SumModeProrated   SumMode = "prorated"
+	SumModeStartsOnly SumMode = "starts_only"
+)
+
+func (m SumMode) Valid() bool {
+	switch m {
+	case SumModeBilled, SumModeProrated, SumModeStartsOnly:
+		return true
+	}
+	return false
+}
+
+const (
+	GroupByServiceName = "service_name"
+	GroupByUserID      = "user_id"
+)
+
+type CostQuery struct {
+	UserID         *uuid.UUID
+	ServiceName    *string
+	StartDate      time.Time
+	EndDate        time.Time
+	Mode           SumMode
+	TargetCurrency string
+	GroupBy        []string
+}
+
+// MaxCostRangeYears limits the window of a cost query: every billing period
+// inside it is computed for every matching subscription.
+const MaxCostRangeYears = 10
+
+var ErrCostRangeTooLong = domainerr.Validation("date_range_too_long", fmt.Sprintf("end_date must be within %d years of start_date", MaxCostRangeYears))
+
+var ErrInvalidCursor = domainerr.Validation("invalid_cursor", "invalid cursor")
+
+var SubscriptionSortFields = []string{
+	"id", "service_name", "price", "user_id", "start_date", "end_date", "created_at", "updated_at",
+}
+
+type SubscriptionFilter struct {
+	ServiceName *string
+	ServiceID   *uint
+	// ServiceNames widens a ServiceID filter to the subscriptions without a
+	// service whose service_name is one of the names.
+	ServiceNames  []string
+	UserID        *uuid.UUID
+	PriceMin      *Amount
+	PriceMax      *Amount
+	StartDateFrom *time.Time
+	StartDateTo   *time.Time
+	EndDateFrom   *time.Time
+	EndDateTo     *time.Time
+	ActiveAt      *time.Time
+	// InTrialAt selects subscriptions whose trial period includes the time.
+	InTrialAt        *time.Time
+	TrialEndDateFrom *time.Time
+	TrialEndDateTo   *time.Time
+}
+
+type SubscriptionListQuery struct {
+	Filter SubscriptionFilter
+	Sort   string
+	Order  string
+	Limit  int
+	Offset int
+	Cursor string
+
+	// WithHistory preloads the price and pause history that billing needs.
+	WithHistory bool
+
+	// IncludeDeleted adds soft-deleted subscriptions to the result,
+	// OnlyDeleted returns nothing but them.
+	IncludeDeleted bool
+	OnlyDeleted    bool
+}
+
+type SubscriptionPage struct {
+	Items      []Subscription
+	Total      int64
+	NextCursor string
+}
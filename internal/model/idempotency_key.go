@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+var (
+	ErrIdempotencyKeyReused = domainerr.Unprocessable("idempotency_key_reused",
+		"idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = domainerr.Conflict("idempotency_key_in_progress",
+		"a request with this idempotency key is still in progress")
+)
+
+// IdempotencyKey remembers the response to a request sent with an
+// Idempotency-Key header. StatusCode is 0 while the request is being
+// processed.
+type IdempotencyKey struct {
+	Key          string    `gorm:"primaryKey;type:varchar(255)"`
+	Scope        string    `gorm:"primaryKey;type:varchar(64)"` // операция, например subscriptions.create
+	Fingerprint  string    `gorm:"type:char(64);not null"`      // sha256 тела запроса
+	StatusCode   int       `gorm:"not null;default:0"`
+	ResponseBody []byte    `gorm:"type:bytea"` // ответ хранится байт в байт
+	CreatedAt    time.Time `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import "github.com/winnamu6/go-subscription-service/internal/domainerr"
+
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	// ImportModeAtomic создаёт все строки в одной транзакции или ни одной
+	ImportModeAtomic = "atomic"
+	// ImportModePartial создаёт корректные строки и пропускает ошибочные
+	ImportModePartial = "partial"
+
+	ImportRowCreated = "created"
+	ImportRowValid   = "valid" // строка корректна, но не сохранена (dry run)
+	ImportRowSkipped = "skipped"
+	ImportRowFailed  = "failed"
+
+	MaxImportRows = 10000
+)
+
+var (
+	ErrUnsupportedImportFormat = domainerr.Validation("unsupported_import_format", "import format must be csv or ndjson")
+	ErrInvalidImportFile       = domainerr.Validation("invalid_import_file", "invalid import file")
+	ErrTooManyImportRows       = domainerr.Validation("too_many_import_rows", "import file has too many rows")
+)
+
+type ImportOptions struct {
+	Format string
+	Mode   string
+	DryRun bool
+}
+
+type ImportRowResult struct {
+	Line           int                    `json:"line"` // номер строки файла, начиная с 1
+	Status         string                 `json:"status" enums:"created,valid,skipped,failed"`
+	SubscriptionID *uint                  `json:"subscription_id,omitempty"`
+	Code           string                 `json:"code,omitempty"`
+	Reason         string                 `json:"reason,omitempty"`
+	Errors         []domainerr.FieldError `json:"errors,omitempty"`
+}
+
+type SubscriptionImportResponse struct {
+	Mode    string            `json:"mode"`
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Valid   int               `json:"valid"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+const (
+	CurrencyRUB = "RUB"
+	CurrencyUSD = "USD"
+	CurrencyEUR = "EUR"
+
+	DefaultCurrency = CurrencyRUB
+
+	// minorDigits is the number of minor unit digits shared by all supported
+	// currencies (kopecks, cents).
+	minorDigits = 2
+	minorScale  = 100
+)
+
+var SupportedCurrencies = []string{CurrencyRUB, CurrencyUSD, CurrencyEUR}
+
+var ErrInvalidAmount = domainerr.Validation("invalid_amount", "invalid amount")
+
+// Amount is a monetary value in minor units (kopecks, cents). It is stored as
+// bigint and serialized to JSON as a decimal number, e.g. 19999 -> 199.99.
+type Amount int64
+
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidAmount
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if intPart == "" || (hasFrac && fracPart == "") {
+		return 0, ErrInvalidAmount
+	}
+	if len(fracPart) > minorDigits {
+		if strings.TrimRight(fracPart[minorDigits:], "0") != "" {
+			return 0, fmt.Errorf("%w: more than %d decimal places", ErrInvalidAmount, minorDigits)
+		}
+		fracPart = fracPart[:minorDigits]
+	}
+	fracPart += strings.Repeat("0", minorDigits-len(fracPart))
+
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return 0, ErrInvalidAmount
+		}
+	}
+
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || units > math.MaxInt64/minorScale-1 {
+		return 0, ErrInvalidAmount
+	}
+	minor, _ := strconv.ParseInt(fracPart, 10, 64)
+
+	v := units*minorScale + minor
+	if neg {
+		v = -v
+	}
+	return Amount(v), nil
+}
+
+func (a Amount) String() string {
+	v := int64(a)
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/minorScale, v%minorScale)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers (199.99) and decimal strings
+// ("199.99") without going through float64.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	raw := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return ErrInvalidAmount
+		}
+	} else if strings.ContainsAny(raw, "eE") {
+		return fmt.Errorf("%w: exponent notation is not supported", ErrInvalidAmount)
+	}
+
+	v, err := ParseAmount(raw)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// UnmarshalParam lets gin bind amounts from query strings.
+func (a *Amount) UnmarshalParam(param string) error {
+	v, err := ParseAmount(param)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// Money is an amount together with its ISO 4217 currency code.
+type Money struct {
+	Amount   Amount `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func IsSupportedCurrency(code string) bool {
+	return slices.Contains(SupportedCurrencies, code)
+}
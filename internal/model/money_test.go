@@ -0,0 +1,128 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "199", want: 19900},
+		{in: "199.9", want: 19990},
+		{in: "199.99", want: 19999},
+		{in: "0.01", want: 1},
+		{in: " 12.50 ", want: 1250},
+		{in: "+5", want: 500},
+		{in: "-5.05", want: -505},
+		{in: "1.230", want: 123},
+		{in: "1.2300000", want: 123},
+		{in: "007.10", want: 710},
+		{in: "92233720368547756.06", want: 9223372036854775606},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".5", wantErr: true},
+		{in: "5.", wantErr: true},
+		{in: "1.005", wantErr: true},
+		{in: "1,5", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "92233720368547758.08", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAmount(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("ParseAmount(%q) error = %v, want ErrInvalidAmount", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	tests := []struct {
+		in   Amount
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 1, want: "0.01"},
+		{in: 19999, want: "199.99"},
+		{in: 100000, want: "1000.00"},
+		{in: -5, want: "-0.05"},
+		{in: -19990, want: "-199.90"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Amount(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{in: `199.99`, want: 19999},
+		{in: `"199.99"`, want: 19999},
+		{in: `1099.9`, want: 109990},
+		{in: `0.1`, want: 10},
+		{in: `"-0.30"`, want: -30},
+		{in: `null`, want: 0},
+		{in: `1e2`, wantErr: true},
+		{in: `"1E2"`, wantErr: true},
+		{in: `0.001`, wantErr: true},
+		{in: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var got Amount
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %d, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountJSONRoundTrip(t *testing.T) {
+	for _, a := range []Amount{0, 1, 10, 19999, -250} {
+		data, err := json.Marshal(a)
+		if err != nil {
+			t.Fatalf("Marshal(%d): %v", int64(a), err)
+		}
+		var got Amount
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if got != a {
+			t.Errorf("round trip of %d gave %d", int64(a), got)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+const (
+	ReminderRenewal    = "renewal"
+	ReminderExpiration = "expiration"
+)
+
+var ErrNotificationPreferencesNotFound = domainerr.NotFound("notification_preferences_not_found",
+	"notification preferences not found")
+
+// NotificationPreference holds where and about what a user is reminded.
+// Users without preferences get no reminders.
+type NotificationPreference struct {
+	UserID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Email               string    `gorm:"type:varchar(320);not null" json:"email"`
+	RenewalReminders    bool      `gorm:"not null;default:true" json:"renewal_reminders"`    // напоминать о списаниях
+	ExpirationReminders bool      `gorm:"not null;default:true" json:"expiration_reminders"` // напоминать об окончании подписки
+	DaysBefore          *int      `json:"days_before,omitempty"`                             // за сколько дней; по умолчанию REMINDER_DAYS_AHEAD
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// SentReminder records a reminder that was sent, so that the same reminder
+// about a subscription and date is never sent twice.
+type SentReminder struct {
+	ID             uint      `gorm:"primaryKey"`
+	SubscriptionID uint      `gorm:"not null;uniqueIndex:idx_sent_reminders_unique"`
+	Kind           string    `gorm:"type:varchar(16);not null;uniqueIndex:idx_sent_reminders_unique"` // renewal или expiration
+	DueDate        time.Time `gorm:"type:date;not null;uniqueIndex:idx_sent_reminders_unique"`        // дата списания или окончания
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Email          string    `gorm:"type:varchar(320);not null"`
+	SentAt         time.Time `gorm:"not null"`
+}
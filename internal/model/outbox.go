@@ -0,0 +1,35 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is the envelope of every published event: outbox payloads, webhook
+// bodies and event sink lines.
+type Event struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type" example:"subscription.created"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// OutboxMessage is an event stored in the same transaction as the change that
+// caused it. The relay publishes pending messages in ID order; messages with
+// the same OrderingKey are never published out of order. A message that keeps
+// failing is parked with FailedAt set so that it stops holding back the
+// messages after it.
+type OutboxMessage struct {
+	ID          uint            `gorm:"primaryKey;index:idx_outbox_messages_pending,where:published_at IS NULL"`
+	EventID     uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex"`
+	EventType   string          `gorm:"type:varchar(64);not null"`
+	OrderingKey string          `gorm:"type:varchar(128);not null;index"` // например subscription:42
+	Payload     json.RawMessage `gorm:"type:jsonb;not null"`              // Event целиком
+	Attempts    int             `gorm:"not null;default:0"`               // неудачные попытки публикации
+	LastError   string          `gorm:"type:text"`
+	CreatedAt   time.Time       `gorm:"not null"`
+	PublishedAt *time.Time      `gorm:"index"`
+	FailedAt    *time.Time      `gorm:"index"`
+}
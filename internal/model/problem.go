@@ -0,0 +1,18 @@
+package model
+
+import "github.com/winnamu6/go-subscription-service/internal/domainerr"
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error response. Code and RequestID are extension
+// members; Errors is set for validation failures.
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      string                 `json:"code"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Errors    []domainerr.FieldError `json:"errors,omitempty"`
+}
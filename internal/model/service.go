@@ -0,0 +1,85 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+var (
+	ErrServiceNotFound  = domainerr.NotFound("service_not_found", "service not found")
+	ErrUnknownService   = domainerr.Validation("unknown_service_id", "service_id does not match a catalog entry")
+	ErrServiceNameTaken = domainerr.Conflict("service_name_taken", "name or alias is already used by another service")
+	ErrServiceInUse     = domainerr.Conflict("service_in_use", "service is referenced by subscriptions")
+	ErrPriceRequired    = domainerr.Validation("price_required", "price is required when the service has no default price")
+	ErrMergeSameService = domainerr.Validation("merge_same_service", "a service cannot be merged into itself")
+)
+
+// Service is an entry of the service catalog. Subscriptions reference it by
+// ServiceID and carry its Name as their service_name; Aliases are other
+// spellings of the name that resolve to the entry.
+type Service struct {
+	ID                     uint      `gorm:"primaryKey" json:"id"`
+	Name                   string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"name"`
+	Aliases                []string  `gorm:"type:jsonb;serializer:json;not null" json:"aliases"`
+	Category               string    `gorm:"type:varchar(64);index" json:"category,omitempty"`
+	Website                string    `gorm:"type:varchar(2048)" json:"website,omitempty"`
+	DefaultPrice           Amount    `gorm:"column:default_price_minor;type:bigint;not null;default:0" json:"default_price,omitempty" swaggertype:"number"`
+	DefaultCurrency        string    `gorm:"type:char(3);not null;default:'RUB'" json:"default_currency"`
+	DefaultBillingPeriod   string    `gorm:"type:varchar(16);not null;default:'monthly'" json:"default_billing_period"`
+	DefaultBillingInterval int       `gorm:"not null;default:1" json:"default_billing_interval"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// Names returns the canonical name followed by the aliases.
+func (s *Service) Names() []string {
+	return append([]string{s.Name}, s.Aliases...)
+}
+
+// NormalizeServiceName folds case, whitespace and "ё" so that spellings like
+// "Yandex Plus" and " yandex  plus" compare equal.
+func NormalizeServiceName(name string) string {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	return strings.ReplaceAll(name, "ё", "е")
+}
+
+// ServiceMatcher resolves service names and aliases to catalog entries.
+type ServiceMatcher struct {
+	byName map[string]*Service
+}
+
+func NewServiceMatcher(services []Service) *ServiceMatcher {
+	m := &ServiceMatcher{byName: make(map[string]*Service)}
+	for i := range services {
+		for _, name := range services[i].Names() {
+			m.byName[NormalizeServiceName(name)] = &services[i]
+		}
+	}
+	return m
+}
+
+// Match returns the entry whose name or alias matches name, or nil.
+func (m *ServiceMatcher) Match(name string) *Service {
+	return m.byName[NormalizeServiceName(name)]
+}
+
+// ServiceMatchReport is the outcome of linking subscriptions without a
+// service_id to the catalog by their service_name.
+type ServiceMatchReport struct {
+	Matched   []ServiceNameMatch     `json:"matched"`
+	Unmatched []UnmatchedServiceName `json:"unmatched"`
+}
+
+type ServiceNameMatch struct {
+	ServiceName   string `json:"service_name"`
+	ServiceID     uint   `json:"service_id"`
+	CanonicalName string `json:"canonical_name"`
+	Subscriptions int64  `json:"subscriptions"`
+}
+
+type UnmatchedServiceName struct {
+	ServiceName   string `json:"service_name"`
+	Subscriptions int64  `json:"subscriptions"`
+}
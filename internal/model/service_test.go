@@ -0,0 +1,64 @@
+package model
+
+import "testing"
+
+func TestNormalizeServiceName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Netflix", want: "netflix"},
+		{in: "Yandex Plus", want: "yandex plus"},
+		{in: "  yandex   PLUS ", want: "yandex plus"},
+		{in: "yandex\tplus\n", want: "yandex plus"},
+		{in: "yandex plus", want: "yandex plus"},
+		{in: "Ёлка", want: "елка"},
+		{in: "КиноПоиск", want: "кинопоиск"},
+		{in: "ещё", want: "еще"},
+		{in: "   ", want: ""},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeServiceName(tt.in); got != tt.want {
+			t.Errorf("NormalizeServiceName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestServiceMatcher(t *testing.T) {
+	m := NewServiceMatcher([]Service{
+		{ID: 1, Name: "Yandex Plus", Aliases: []string{"Яндекс Плюс", "Yandex.Plus"}},
+		{ID: 2, Name: "Netflix"},
+		{ID: 3, Name: "Ёлки Music"},
+	})
+
+	tests := []struct {
+		name string
+		want uint // 0 means no match
+	}{
+		{name: "Yandex Plus", want: 1},
+		{name: "yandex  plus", want: 1},
+		{name: "ЯНДЕКС ПЛЮС", want: 1},
+		{name: "yandex.plus", want: 1},
+		{name: " Netflix ", want: 2},
+		{name: "елки music", want: 3},
+		{name: "Yandex", want: 0},
+		{name: "YandexPlus", want: 0},
+		{name: "", want: 0},
+	}
+	for _, tt := range tests {
+		var got uint
+		if svc := m.Match(tt.name); svc != nil {
+			got = svc.ID
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestServiceMatcherEmptyCatalog(t *testing.T) {
+	if svc := NewServiceMatcher(nil).Match("Netflix"); svc != nil {
+		t.Errorf("Match() = %+v, want nil", svc)
+	}
+}
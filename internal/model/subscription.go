@@ -4,24 +4,83 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
 	"gorm.io/gorm"
 )
 
+const (
+	BillingPeriodWeekly    = "weekly"
+	BillingPeriodMonthly   = "monthly"
+	BillingPeriodQuarterly = "quarterly"
+	BillingPeriodYearly    = "yearly"
+	BillingPeriodCustom    = "custom"
+)
+
+var (
+	ErrSubscriptionNotFound        = domainerr.NotFound("subscription_not_found", "subscription not found")
+	ErrDeletedSubscriptionNotFound = domainerr.NotFound("deleted_subscription_not_found", "deleted subscription not found")
+	ErrInvalidUserID               = domainerr.Validation("invalid_user_id", "invalid user_id")
+
+	ErrInvalidDateRange          = domainerr.Validation("invalid_date_range", "end_date cannot be before start_date")
+	ErrBillingIntervalRequired   = domainerr.Validation("billing_interval_required", "billing_interval is required for custom billing_period")
+	ErrPriceEffectiveFromInvalid = domainerr.Validation("price_effective_from_without_price", "price_effective_from requires price or currency")
+	ErrInvalidTrialPeriod        = domainerr.Validation("invalid_trial_period", "trial_end_date must be after start_date")
+	ErrTrialPriceWithoutTrial    = domainerr.Validation("trial_price_without_trial", "trial_price requires trial_end_date")
+
+	// ErrVersionMismatch means the version sent in If-Match is not the current
+	// version of the subscription.
+	ErrVersionMismatch = domainerr.PreconditionFailed("version_mismatch", "subscription version does not match")
+	// ErrConcurrentUpdate means the subscription changed between reading and
+	// writing it.
+	ErrConcurrentUpdate = domainerr.Conflict("concurrent_update", "subscription was modified concurrently")
+)
+
 type Subscription struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	ServiceName string         `gorm:"type:varchar(255);not null" json:"service_name"`
-	Price       int            `gorm:"not null" json:"price"` // целое число рублей
-	UserID      uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
-	StartDate   time.Time      `gorm:"not null" json:"start_date"`
-	EndDate     *time.Time     `json:"end_date,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	ServiceName     string         `gorm:"type:varchar(255);not null" json:"service_name"`
+	ServiceID       *uint          `gorm:"index" json:"service_id,omitempty"`
+	Price           Amount         `gorm:"column:price_minor;type:bigint;not null;default:0" json:"price" swaggertype:"number"` // хранится в копейках
+	Currency        string         `gorm:"type:char(3);not null;default:'RUB'" json:"currency"`
+	UserID          uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	StartDate       time.Time      `gorm:"not null" json:"start_date"`
+	EndDate         *time.Time     `json:"end_date,omitempty"`
+	TrialEndDate    *time.Time     `gorm:"index" json:"trial_end_date,omitempty"`
+	TrialPrice      Amount         `gorm:"column:trial_price_minor;type:bigint;not null;default:0" json:"trial_price" swaggertype:"number"`
+	BillingPeriod   string         `gorm:"type:varchar(16);not null;default:'monthly'" json:"billing_period"`
+	BillingInterval int            `gorm:"not null;default:1" json:"billing_interval"` // для custom — длина периода в днях
+	Version         int            `gorm:"not null;default:1" json:"version"`          // увеличивается при каждом изменении
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	ExpiredAt       *time.Time     `json:"-"` // когда по подписке отправлено событие subscription.expired
+	TrialEndedAt    *time.Time     `json:"-"` // когда по подписке отправлено событие subscription.trial_converted
+	PausedAt        *time.Time     `json:"-"` // текущая или последняя пауза, история — в Pauses
+	ResumeAt        *time.Time     `json:"-"`
+	CanceledAt      *time.Time     `json:"-"` // когда подписка отменена через POST /subscriptions/{id}/cancel
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Prices []SubscriptionPrice `gorm:"foreignKey:SubscriptionID" json:"-"`
+	Pauses []SubscriptionPause `gorm:"foreignKey:SubscriptionID" json:"-"`
+}
+
+// HasTrial reports whether the subscription starts with a trial period. The
+// trial lasts from StartDate to TrialEndDate and costs TrialPrice in the
+// subscription currency (nothing by default); regular billing starts at
+// TrialEndDate.
+func (s *Subscription) HasTrial() bool {
+	return s.TrialEndDate != nil && s.TrialEndDate.After(s.StartDate)
+}
+
+// InTrial reports whether t falls within the trial period.
+func (s *Subscription) InTrial(t time.Time) bool {
+	return s.HasTrial() && !t.Before(s.StartDate) && t.Before(*s.TrialEndDate)
 }
 
 func (s *Subscription) BeforeCreate(tx *gorm.DB) (err error) {
 	s.CreatedAt = time.Now()
 	s.UpdatedAt = time.Now()
+	if s.Version == 0 {
+		s.Version = 1
+	}
 	return
 }
 
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+const (
+	CancelModeImmediate   = "immediate"
+	CancelModeAtPeriodEnd = "at_period_end"
+
+	CancelReasonTooExpensive    = "too_expensive"
+	CancelReasonNotUsing        = "not_using"
+	CancelReasonSwitchedService = "switched_service"
+	CancelReasonMissingFeatures = "missing_features"
+	CancelReasonTechnicalIssues = "technical_issues"
+	CancelReasonOther           = "other"
+)
+
+var ErrSubscriptionCanceled = domainerr.Conflict("subscription_already_canceled", "subscription is already canceled")
+
+// SubscriptionCancellation records why and how a subscription was canceled.
+// ServiceName is copied from the subscription so that churn can be grouped
+// without joining subscriptions. RevertedAt is set when the cancellation is
+// taken back by removing the end date; reverted cancellations are not churn.
+type SubscriptionCancellation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint       `gorm:"not null;index" json:"subscription_id"`
+	ServiceName    string     `gorm:"type:varchar(255);not null;index" json:"service_name"`
+	Mode           string     `gorm:"type:varchar(16);not null" json:"mode"`
+	Reason         string     `gorm:"type:varchar(32);not null" json:"reason"`
+	Comment        string     `gorm:"type:text" json:"comment,omitempty"`
+	EndDate        time.Time  `gorm:"not null" json:"end_date"` // дата окончания подписки после отмены
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+	RevertedAt     *time.Time `json:"reverted_at,omitempty"`
+}
+
+type ChurnFilter struct {
+	ServiceName *string
+	From        *time.Time
+	To          *time.Time
+}
+
+// ChurnRow is the number of cancellations of one service with one reason.
+type ChurnRow struct {
+	ServiceName string
+	Reason      string
+	Count       int64
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+)
+
+const (
+	SubscriptionStatusActive = "active"
+	SubscriptionStatusPaused = "paused"
+	SubscriptionStatusEnded  = "ended"
+	SubscriptionStatusTrial  = "trial"
+)
+
+var (
+	ErrSubscriptionPaused    = domainerr.Conflict("subscription_already_paused", "subscription is already paused")
+	ErrSubscriptionNotPaused = domainerr.Conflict("subscription_not_paused", "subscription is not paused")
+	ErrSubscriptionEnded     = domainerr.Conflict("subscription_ended", "subscription has ended")
+	ErrInvalidResumeDate     = domainerr.Validation("invalid_resume_date", "resume_at must be in the future")
+)
+
+// SubscriptionPause is an entry of the pause history of a subscription.
+// Nothing is billed from PausedAt until ResumeAt; a pause without ResumeAt
+// lasts until the subscription is resumed.
+type SubscriptionPause struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint       `gorm:"not null;index" json:"subscription_id"`
+	PausedAt       time.Time  `gorm:"not null" json:"paused_at"`
+	ResumeAt       *time.Time `json:"resume_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Covers reports whether t falls within the pause.
+func (p *SubscriptionPause) Covers(t time.Time) bool {
+	return !t.Before(p.PausedAt) && (p.ResumeAt == nil || t.Before(*p.ResumeAt))
+}
+
+// PauseHistory returns the loaded pause history of sub or, when it is not
+// loaded, just the current pause.
+func (s *Subscription) PauseHistory() []SubscriptionPause {
+	if len(s.Pauses) > 0 || s.PausedAt == nil {
+		return s.Pauses
+	}
+	return []SubscriptionPause{{SubscriptionID: s.ID, PausedAt: *s.PausedAt, ResumeAt: s.ResumeAt}}
+}
+
+// PausedOn reports whether sub is paused at t.
+func (s *Subscription) PausedOn(t time.Time) bool {
+	for _, p := range s.PauseHistory() {
+		if p.Covers(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Status derives the state of the subscription at now. An ended subscription
+// is ended even if it was paused, and a pause takes precedence over a trial.
+func (s *Subscription) Status(now time.Time) string {
+	switch {
+	case s.EndDate != nil && s.EndDate.Before(now):
+		return SubscriptionStatusEnded
+	case s.PausedOn(now):
+		return SubscriptionStatusPaused
+	case s.InTrial(now):
+		return SubscriptionStatusTrial
+	default:
+		return SubscriptionStatusActive
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// SubscriptionPrice is an entry of the price history of a subscription. The
+// price applies to every billing period that starts at or after
+// EffectiveFrom, until the next entry.
+type SubscriptionPrice struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint      `gorm:"not null;uniqueIndex:idx_subscription_prices_effective" json:"subscription_id"`
+	Amount         Amount    `gorm:"column:amount_minor;type:bigint;not null" json:"amount" swaggertype:"number"`
+	Currency       string    `gorm:"type:char(3);not null" json:"currency"`
+	EffectiveFrom  time.Time `gorm:"not null;uniqueIndex:idx_subscription_prices_effective" json:"effective_from"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PriceAt returns the price of sub in effect at t. Dates before the first
+// history entry use the earliest known price; subscriptions without loaded
+// history use their current price.
+func (s *Subscription) PriceAt(t time.Time) Money {
+	current := Money{Amount: s.Price, Currency: s.Currency}
+	var found *SubscriptionPrice
+	for i := range s.Prices {
+		p := &s.Prices[i]
+		if p.EffectiveFrom.After(t) {
+			continue
+		}
+		if found == nil || p.EffectiveFrom.After(found.EffectiveFrom) {
+			found = p
+		}
+	}
+	if found == nil {
+		for i := range s.Prices {
+			p := &s.Prices[i]
+			if found == nil || p.EffectiveFrom.Before(found.EffectiveFrom) {
+				found = p
+			}
+		}
+	}
+	if found != nil {
+		current = Money{Amount: found.Amount, Currency: found.Currency}
+	}
+	return current
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"gorm.io/gorm"
+)
+
+const (
+	EventSubscriptionCreated = "subscription.created"
+	EventSubscriptionUpdated = "subscription.updated"
+	EventSubscriptionDeleted = "subscription.deleted"
+	EventSubscriptionExpired = "subscription.expired"
+	// EventSubscriptionRestored is emitted when a soft-deleted subscription
+	// is restored.
+	EventSubscriptionRestored = "subscription.restored"
+	// EventSubscriptionTrialConverted is emitted when the trial ends and
+	// regular billing starts.
+	EventSubscriptionTrialConverted = "subscription.trial_converted"
+	// EventSubscriptionCanceled is emitted when a subscription is canceled,
+	// immediately or at the end of its billing period.
+	EventSubscriptionCanceled = "subscription.canceled"
+	// EventSubscriptionPaused and EventSubscriptionResumed are emitted when
+	// a subscription is paused or resumed by hand.
+	EventSubscriptionPaused  = "subscription.paused"
+	EventSubscriptionResumed = "subscription.resumed"
+
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookEventTypes lists the events endpoints can subscribe to.
+var WebhookEventTypes = []string{
+	EventSubscriptionCreated,
+	EventSubscriptionUpdated,
+	EventSubscriptionDeleted,
+	EventSubscriptionRestored,
+	EventSubscriptionExpired,
+	EventSubscriptionTrialConverted,
+	EventSubscriptionCanceled,
+	EventSubscriptionPaused,
+	EventSubscriptionResumed,
+}
+
+var (
+	ErrWebhookNotFound         = domainerr.NotFound("webhook_not_found", "webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = domainerr.NotFound("webhook_delivery_not_found", "webhook delivery not found")
+)
+
+// WebhookEndpoint receives the events listed in EventTypes. Endpoints are
+// soft-deleted so that their delivery log stays available.
+type WebhookEndpoint struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	URL        string         `gorm:"type:varchar(2048);not null" json:"url"`
+	Secret     string         `gorm:"type:varchar(255);not null" json:"-"`                    // ключ подписи HMAC-SHA256
+	EventTypes []string       `gorm:"type:jsonb;serializer:json;not null" json:"event_types"` // например ["subscription.created"]
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// WebhookDelivery is one event sent to one endpoint, together with the outcome
+// of its last attempt. Pending deliveries are retried at NextAttemptAt.
+type WebhookDelivery struct {
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	EndpointID     uint            `gorm:"not null;index;uniqueIndex:idx_webhook_deliveries_event,where:replay_of IS NULL" json:"endpoint_id"`
+	EventID        uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_event,where:replay_of IS NULL" json:"event_id"`
+	EventType      string          `gorm:"type:varchar(64);not null" json:"event_type"`
+	Payload        json.RawMessage `gorm:"type:jsonb;not null" json:"payload" swaggertype:"object"`
+	Status         string          `gorm:"type:varchar(16);not null;index:idx_webhook_deliveries_due" json:"status"`
+	Attempts       int             `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  *time.Time      `gorm:"index:idx_webhook_deliveries_due" json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	ResponseStatus int             `gorm:"not null;default:0" json:"response_status,omitempty"` // HTTP-код последней попытки
+	LastError      string          `gorm:"type:text" json:"last_error,omitempty"`
+	ReplayOf       *uint           `json:"replay_of,omitempty"` // доставка, которую повторили вручную
+	CreatedAt      time.Time       `gorm:"index" json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type WebhookDeliveryFilter struct {
+	EndpointID uint
+	Status     *string
+	EventType  *string
+	Limit      int
+	Offset     int
+}
+
+type WebhookDeliveryListResponse struct {
+	Items  []WebhookDelivery `json:"items"`
+	Total  int64             `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
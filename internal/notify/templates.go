@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// ReminderData is passed to the reminder templates.
+type ReminderData struct {
+	Email        string
+	Subscription model.SubscriptionResponse
+	// DueDate is the billing date for renewal reminders and the end date for
+	// expiration reminders.
+	DueDate  time.Time
+	DaysLeft int
+}
+
+// Templates renders reminders. Each reminder kind has a template file
+// "<kind>.tmpl" that defines a "subject" and a "body" template.
+type Templates struct {
+	kinds map[string]*template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("02.01.2006") },
+}
+
+// LoadTemplates parses the built-in templates and then those in dir, if set,
+// so that files in dir replace the built-in ones of the same kind.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{kinds: make(map[string]*template.Template)}
+
+	builtIn, err := fs.Glob(defaultTemplates, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range builtIn {
+		content, err := defaultTemplates.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.parse(file, content); err != nil {
+			return nil, err
+		}
+	}
+
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			if err := t.parse(file, content); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return t, nil
+}
+
+func (t *Templates) parse(file string, content []byte) error {
+	kind := strings.TrimSuffix(filepath.Base(file), ".tmpl")
+	tmpl, err := template.New(kind).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("template %s: %w", file, err)
+	}
+	for _, name := range []string{"subject", "body"} {
+		if tmpl.Lookup(name) == nil {
+			return fmt.Errorf("template %s does not define %q", file, name)
+		}
+	}
+	t.kinds[kind] = tmpl
+	return nil
+}
+
+// Render returns the message of a reminder of the given kind.
+func (t *Templates) Render(kind string, data ReminderData) (Message, error) {
+	tmpl, ok := t.kinds[kind]
+	if !ok {
+		return Message{}, fmt.Errorf("no template for %s reminders", kind)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      data.Email,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
@@ -0,0 +1,81 @@
+package patch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJSONPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{name: "add member", doc: `{"a":1}`, patch: `[{"op":"add","path":"/b","value":2}]`, want: `{"a":1,"b":2}`},
+		{name: "add replaces member", doc: `{"a":1}`, patch: `[{"op":"add","path":"/a","value":2}]`, want: `{"a":2}`},
+		{name: "add into array", doc: `{"a":[1,3]}`, patch: `[{"op":"add","path":"/a/1","value":2}]`, want: `{"a":[1,2,3]}`},
+		{name: "append to array", doc: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/-","value":2}]`, want: `{"a":[1,2]}`},
+		{name: "add null", doc: `{}`, patch: `[{"op":"add","path":"/a","value":null}]`, want: `{"a":null}`},
+		{name: "remove member", doc: `{"a":1,"b":2}`, patch: `[{"op":"remove","path":"/a"}]`, want: `{"b":2}`},
+		{name: "remove array element", doc: `[1,2,3]`, patch: `[{"op":"remove","path":"/1"}]`, want: `[1,3]`},
+		{name: "replace member", doc: `{"a":{"b":1}}`, patch: `[{"op":"replace","path":"/a/b","value":"x"}]`, want: `{"a":{"b":"x"}}`},
+		{name: "replace whole document", doc: `{"a":1}`, patch: `[{"op":"replace","path":"","value":[1]}]`, want: `[1]`},
+		{name: "move member", doc: `{"a":1}`, patch: `[{"op":"move","from":"/a","path":"/b"}]`, want: `{"b":1}`},
+		{name: "copy member", doc: `{"a":{"b":1}}`, patch: `[{"op":"copy","from":"/a","path":"/c"}]`, want: `{"a":{"b":1},"c":{"b":1}}`},
+		{name: "test passes", doc: `{"a":1}`, patch: `[{"op":"test","path":"/a","value":1.0}]`, want: `{"a":1}`},
+		{name: "escaped pointer", doc: `{"a/b":1,"c~d":2}`, patch: `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/c~0d"}]`, want: `{}`},
+		{name: "operations apply in order", doc: `{}`, patch: `[{"op":"add","path":"/a","value":1},{"op":"replace","path":"/a","value":2}]`, want: `{"a":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("JSONPatch unexpected error: %v", err)
+			}
+			if !sameJSON(t, got, []byte(tt.want)) {
+				t.Errorf("JSONPatch(%s, %s) = %s, want %s", tt.doc, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  error
+	}{
+		{name: "not an array", doc: `{}`, patch: `{"op":"add"}`, want: ErrInvalidPatch},
+		{name: "unknown op", doc: `{}`, patch: `[{"op":"merge","path":"/a"}]`, want: ErrInvalidPatch},
+		{name: "missing value", doc: `{}`, patch: `[{"op":"add","path":"/a"}]`, want: ErrInvalidPatch},
+		{name: "path without slash", doc: `{}`, patch: `[{"op":"add","path":"a","value":1}]`, want: ErrInvalidPatch},
+		{name: "leading zero index", doc: `[1,2]`, patch: `[{"op":"remove","path":"/01"}]`, want: ErrInvalidPatch},
+		{name: "move into itself", doc: `{"a":{}}`, patch: `[{"op":"move","from":"/a","path":"/a/b"}]`, want: ErrInvalidPatch},
+		{name: "remove missing member", doc: `{}`, patch: `[{"op":"remove","path":"/a"}]`, want: ErrPatchNotApplicable},
+		{name: "replace missing member", doc: `{}`, patch: `[{"op":"replace","path":"/a","value":1}]`, want: ErrPatchNotApplicable},
+		{name: "index out of range", doc: `[1]`, patch: `[{"op":"add","path":"/2","value":1}]`, want: ErrPatchNotApplicable},
+		{name: "add below scalar", doc: `{"a":1}`, patch: `[{"op":"add","path":"/a/b","value":1}]`, want: ErrPatchNotApplicable},
+		{name: "test fails", doc: `{"a":1}`, patch: `[{"op":"test","path":"/a","value":2}]`, want: ErrPatchNotApplicable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := JSONPatch([]byte(tt.doc), []byte(tt.patch))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("JSONPatch error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPatchIsAtomic(t *testing.T) {
+	doc := []byte(`{"a":1}`)
+	_, err := JSONPatch(doc, []byte(`[{"op":"add","path":"/b","value":2},{"op":"remove","path":"/c"}]`))
+	if err == nil {
+		t.Fatal("JSONPatch succeeded, want error")
+	}
+	if string(doc) != `{"a":1}` {
+		t.Errorf("document changed to %s", doc)
+	}
+}
@@ -0,0 +1,73 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// sameJSON reports whether a and b encode the same JSON value.
+func sameJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var x, y any
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{name: "replace member", doc: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add member", doc: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "null removes member", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "null for missing member", doc: `{"a":"b"}`, patch: `{"c":null}`, want: `{"a":"b"}`},
+		{name: "array replaced whole", doc: `{"a":["b"]}`, patch: `{"a":["c","d"]}`, want: `{"a":["c","d"]}`},
+		{name: "nested merge", doc: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"d":null,"f":"g"}}`, want: `{"a":{"b":"c","f":"g"}}`},
+		{name: "object replaces scalar", doc: `{"a":"b"}`, patch: `{"a":{"c":"d"}}`, want: `{"a":{"c":"d"}}`},
+		{name: "nested null inside new object", doc: `{}`, patch: `{"a":{"b":null,"c":"d"}}`, want: `{"a":{"c":"d"}}`},
+		{name: "non-object patch replaces document", doc: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+		{name: "null patch", doc: `{"a":"b"}`, patch: `null`, want: `null`},
+		{name: "empty patch keeps document", doc: `{"a":"b"}`, patch: `{}`, want: `{"a":"b"}`},
+		{name: "numbers keep their digits", doc: `{"price":"1.00"}`, patch: `{"price":199.99}`, want: `{"price":199.99}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("MergePatch unexpected error: %v", err)
+			}
+			if !sameJSON(t, got, []byte(tt.want)) {
+				t.Errorf("MergePatch(%s, %s) = %s, want %s", tt.doc, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatchKeepsNumberText(t *testing.T) {
+	got, err := MergePatch([]byte(`{"price":1}`), []byte(`{"price":12345678901234567.89}`))
+	if err != nil {
+		t.Fatalf("MergePatch unexpected error: %v", err)
+	}
+	if want := `{"price":12345678901234567.89}`; string(got) != want {
+		t.Errorf("MergePatch = %s, want %s", got, want)
+	}
+}
+
+func TestMergePatchRejectsInvalidPatch(t *testing.T) {
+	for _, p := range []string{``, `{`, `{"a":1} {"b":2}`, `nope`} {
+		_, err := MergePatch([]byte(`{"a":"b"}`), []byte(p))
+		if !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("MergePatch(%q) error = %v, want ErrInvalidPatch", p, err)
+		}
+	}
+}
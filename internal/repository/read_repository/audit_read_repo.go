@@ -0,0 +1,11 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type AuditReadRepository interface {
+	List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, int64, error)
+}
@@ -0,0 +1,66 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type auditReadRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditReadRepo(db *gorm.DB) AuditReadRepository {
+	return &auditReadRepo{db: db}
+}
+
+// List returns events matching filter, newest first, together with the total
+// number of matching events.
+func (r *auditReadRepo) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, int64, error) {
+	log := logger.Get()
+	log.Infof("[AuditReadRepo] List called | filter=%+v", filter)
+
+	q := r.db.WithContext(ctx).Model(&model.AuditEvent{})
+	if filter.EntityType != nil {
+		q = q.Where("entity_type = ?", *filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		q = q.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != nil {
+		q = q.Where("action = ?", *filter.Action)
+	}
+	if filter.Actor != nil {
+		q = q.Where("actor = ?", *filter.Actor)
+	}
+	if filter.RequestID != nil {
+		q = q.Where("request_id = ?", *filter.RequestID)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		log.Errorf("[AuditReadRepo] List count error | err=%v", err)
+		return nil, 0, err
+	}
+
+	var events []model.AuditEvent
+	err := q.Order("created_at DESC").Order("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&events).Error
+	if err != nil {
+		log.Errorf("[AuditReadRepo] List error | err=%v", err)
+		return nil, 0, err
+	}
+
+	log.Infof("[AuditReadRepo] List success | count=%d total=%d", len(events), total)
+	return events, total, nil
+}
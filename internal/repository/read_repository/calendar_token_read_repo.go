@@ -0,0 +1,12 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type CalendarTokenReadRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*model.CalendarToken, error)
+}
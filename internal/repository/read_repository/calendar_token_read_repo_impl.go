@@ -0,0 +1,40 @@
+package read_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type calendarTokenReadRepo struct {
+	db *gorm.DB
+}
+
+func NewCalendarTokenReadRepo(db *gorm.DB) CalendarTokenReadRepository {
+	return &calendarTokenReadRepo{db: db}
+}
+
+// GetByUserID returns the calendar token of a user, or
+// model.ErrCalendarNotFound if none was issued.
+func (r *calendarTokenReadRepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*model.CalendarToken, error) {
+	log := logger.Get()
+	log.Infof("[CalendarTokenReadRepo] GetByUserID called | userID=%s", userID)
+
+	var token model.CalendarToken
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[CalendarTokenReadRepo] GetByUserID not found | userID=%s", userID)
+			return nil, model.ErrCalendarNotFound
+		}
+		log.Errorf("[CalendarTokenReadRepo] GetByUserID error | userID=%s err=%v", userID, err)
+		return nil, err
+	}
+
+	log.Infof("[CalendarTokenReadRepo] GetByUserID success | userID=%s", userID)
+	return &token, nil
+}
@@ -0,0 +1,35 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type chargeReadRepo struct {
+	db *gorm.DB
+}
+
+func NewChargeReadRepo(db *gorm.DB) ChargeReadRepository {
+	return &chargeReadRepo{db: db}
+}
+
+func (r *chargeReadRepo) GetBySubscriptionID(ctx context.Context, subscriptionID uint) ([]model.Charge, error) {
+	log := logger.Get()
+	log.Infof("[ChargeReadRepo] GetBySubscriptionID called | subscriptionID=%d", subscriptionID)
+
+	var charges []model.Charge
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("period_start").
+		Find(&charges).Error
+	if err != nil {
+		log.Errorf("[ChargeReadRepo] GetBySubscriptionID error | subscriptionID=%d err=%v", subscriptionID, err)
+		return nil, err
+	}
+
+	log.Infof("[ChargeReadRepo] GetBySubscriptionID success | subscriptionID=%d count=%d", subscriptionID, len(charges))
+	return charges, nil
+}
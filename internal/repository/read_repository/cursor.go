@@ -0,0 +1,71 @@
+package read_repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+// sortColumns maps public sort keys to SQL expressions. Nullable columns are
+// coalesced so that keyset comparisons stay total.
+var sortColumns = map[string]string{
+	"id":           "id",
+	"service_name": "service_name",
+	"price":        "price_minor",
+	"user_id":      "user_id",
+	"start_date":   "start_date",
+	"end_date":     "COALESCE(end_date, 'infinity'::timestamptz)",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+type cursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, model.ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, model.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func sortValue(sub *model.Subscription, field string) string {
+	switch field {
+	case "service_name":
+		return sub.ServiceName
+	case "price":
+		return strconv.FormatInt(int64(sub.Price), 10)
+	case "user_id":
+		return sub.UserID.String()
+	case "start_date":
+		return sub.StartDate.Format(time.RFC3339Nano)
+	case "end_date":
+		if sub.EndDate == nil {
+			return "infinity"
+		}
+		return sub.EndDate.Format(time.RFC3339Nano)
+	case "created_at":
+		return sub.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		return sub.UpdatedAt.Format(time.RFC3339Nano)
+	default:
+		return strconv.FormatUint(uint64(sub.ID), 10)
+	}
+}
@@ -0,0 +1,13 @@
+package read_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type ExchangeRateReadRepository interface {
+	GetAll(ctx context.Context, currency *string) ([]model.ExchangeRate, error)
+	GetEffectiveUntil(ctx context.Context, until time.Time) ([]model.ExchangeRate, error)
+}
@@ -0,0 +1,55 @@
+package read_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type exchangeRateReadRepo struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateReadRepo(db *gorm.DB) ExchangeRateReadRepository {
+	return &exchangeRateReadRepo{db: db}
+}
+
+func (r *exchangeRateReadRepo) GetAll(ctx context.Context, currency *string) ([]model.ExchangeRate, error) {
+	log := logger.Get()
+	log.Infof("[ExchangeRateReadRepo] GetAll called | currency=%v", currency)
+
+	query := r.db.WithContext(ctx).Model(&model.ExchangeRate{})
+	if currency != nil && *currency != "" {
+		query = query.Where("from_currency = ? OR to_currency = ?", *currency, *currency)
+	}
+
+	var rates []model.ExchangeRate
+	if err := query.Order("effective_date DESC, from_currency, to_currency").Find(&rates).Error; err != nil {
+		log.Errorf("[ExchangeRateReadRepo] GetAll error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[ExchangeRateReadRepo] GetAll success | count=%d", len(rates))
+	return rates, nil
+}
+
+func (r *exchangeRateReadRepo) GetEffectiveUntil(ctx context.Context, until time.Time) ([]model.ExchangeRate, error) {
+	log := logger.Get()
+	log.Infof("[ExchangeRateReadRepo] GetEffectiveUntil called | until=%s", until.Format(time.RFC3339))
+
+	var rates []model.ExchangeRate
+	err := r.db.WithContext(ctx).
+		Where("effective_date <= ?", until).
+		Order("effective_date").
+		Find(&rates).Error
+	if err != nil {
+		log.Errorf("[ExchangeRateReadRepo] GetEffectiveUntil error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[ExchangeRateReadRepo] GetEffectiveUntil success | count=%d", len(rates))
+	return rates, nil
+}
@@ -0,0 +1,13 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type NotificationReadRepository interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreference, error)
+	ListPreferences(ctx context.Context, after uuid.UUID, limit int) ([]model.NotificationPreference, error)
+}
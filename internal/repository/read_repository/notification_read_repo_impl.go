@@ -0,0 +1,60 @@
+package read_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type notificationReadRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationReadRepo(db *gorm.DB) NotificationReadRepository {
+	return &notificationReadRepo{db: db}
+}
+
+func (r *notificationReadRepo) GetPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreference, error) {
+	log := logger.Get()
+	log.Infof("[NotificationReadRepo] GetPreferences called | userID=%s", userID)
+
+	var pref model.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&pref).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[NotificationReadRepo] GetPreferences not found | userID=%s", userID)
+			return nil, model.ErrNotificationPreferencesNotFound
+		}
+		log.Errorf("[NotificationReadRepo] GetPreferences error | userID=%s err=%v", userID, err)
+		return nil, err
+	}
+
+	log.Infof("[NotificationReadRepo] GetPreferences success | userID=%s", userID)
+	return &pref, nil
+}
+
+// ListPreferences returns up to limit preferences with at least one kind of
+// reminder enabled, ordered by user ID and starting after the given one.
+func (r *notificationReadRepo) ListPreferences(ctx context.Context, after uuid.UUID, limit int) ([]model.NotificationPreference, error) {
+	log := logger.Get()
+	log.Infof("[NotificationReadRepo] ListPreferences called | after=%s limit=%d", after, limit)
+
+	var prefs []model.NotificationPreference
+	err := r.db.WithContext(ctx).
+		Where("user_id > ?", after).
+		Where("renewal_reminders OR expiration_reminders").
+		Order("user_id").
+		Limit(limit).
+		Find(&prefs).Error
+	if err != nil {
+		log.Errorf("[NotificationReadRepo] ListPreferences error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[NotificationReadRepo] ListPreferences success | count=%d", len(prefs))
+	return prefs, nil
+}
@@ -0,0 +1,12 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type ServiceReadRepository interface {
+	List(ctx context.Context) ([]model.Service, error)
+	GetByID(ctx context.Context, id uint) (*model.Service, error)
+}
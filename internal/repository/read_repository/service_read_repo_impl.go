@@ -0,0 +1,50 @@
+package read_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type serviceReadRepo struct {
+	db *gorm.DB
+}
+
+func NewServiceReadRepo(db *gorm.DB) ServiceReadRepository {
+	return &serviceReadRepo{db: db}
+}
+
+func (r *serviceReadRepo) List(ctx context.Context) ([]model.Service, error) {
+	log := logger.Get()
+	log.Info("[ServiceReadRepo] List called")
+
+	var services []model.Service
+	if err := r.db.WithContext(ctx).Order("name").Find(&services).Error; err != nil {
+		log.Errorf("[ServiceReadRepo] List error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[ServiceReadRepo] List success | count=%d", len(services))
+	return services, nil
+}
+
+func (r *serviceReadRepo) GetByID(ctx context.Context, id uint) (*model.Service, error) {
+	log := logger.Get()
+	log.Infof("[ServiceReadRepo] GetByID called | id=%d", id)
+
+	var svc model.Service
+	if err := r.db.WithContext(ctx).First(&svc, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[ServiceReadRepo] GetByID not found | id=%d", id)
+			return nil, model.ErrServiceNotFound
+		}
+		log.Errorf("[ServiceReadRepo] GetByID error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[ServiceReadRepo] GetByID success | id=%d", id)
+	return &svc, nil
+}
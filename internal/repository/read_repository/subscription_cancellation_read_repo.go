@@ -0,0 +1,13 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type SubscriptionCancellationReadRepository interface {
+	// CountByReason counts the cancellations matching f per service and
+	// reason, ordered by service name and then by count, largest first.
+	CountByReason(ctx context.Context, f model.ChurnFilter) ([]model.ChurnRow, error)
+}
@@ -0,0 +1,49 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type subscriptionCancellationReadRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionCancellationReadRepo(db *gorm.DB) SubscriptionCancellationReadRepository {
+	return &subscriptionCancellationReadRepo{db: db}
+}
+
+func (r *subscriptionCancellationReadRepo) CountByReason(ctx context.Context, f model.ChurnFilter) ([]model.ChurnRow, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionCancellationReadRepo] CountByReason called | serviceName=%v from=%v to=%v", f.ServiceName, f.From, f.To)
+
+	query := r.db.WithContext(ctx).
+		Model(&model.SubscriptionCancellation{}).
+		Select("service_name, reason, COUNT(*) AS count").
+		Where("reverted_at IS NULL")
+	if f.ServiceName != nil {
+		query = query.Where("service_name = ?", *f.ServiceName)
+	}
+	if f.From != nil {
+		query = query.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("created_at <= ?", *f.To)
+	}
+
+	var rows []model.ChurnRow
+	err := query.
+		Group("service_name, reason").
+		Order("service_name, count DESC, reason").
+		Scan(&rows).Error
+	if err != nil {
+		log.Errorf("[SubscriptionCancellationReadRepo] CountByReason error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionCancellationReadRepo] CountByReason success | rows=%d", len(rows))
+	return rows, nil
+}
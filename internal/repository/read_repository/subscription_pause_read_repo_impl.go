@@ -0,0 +1,35 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type subscriptionPauseReadRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionPauseReadRepo(db *gorm.DB) SubscriptionPauseReadRepository {
+	return &subscriptionPauseReadRepo{db: db}
+}
+
+func (r *subscriptionPauseReadRepo) GetBySubscriptionID(ctx context.Context, subscriptionID uint) ([]model.SubscriptionPause, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionPauseReadRepo] GetBySubscriptionID called | subscriptionID=%d", subscriptionID)
+
+	var pauses []model.SubscriptionPause
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("paused_at, id").
+		Find(&pauses).Error
+	if err != nil {
+		log.Errorf("[SubscriptionPauseReadRepo] GetBySubscriptionID error | subscriptionID=%d err=%v", subscriptionID, err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionPauseReadRepo] GetBySubscriptionID success | subscriptionID=%d count=%d", subscriptionID, len(pauses))
+	return pauses, nil
+}
@@ -0,0 +1,11 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type SubscriptionPriceReadRepository interface {
+	GetBySubscriptionID(ctx context.Context, subscriptionID uint) ([]model.SubscriptionPrice, error)
+}
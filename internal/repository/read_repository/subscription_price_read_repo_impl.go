@@ -0,0 +1,35 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type subscriptionPriceReadRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionPriceReadRepo(db *gorm.DB) SubscriptionPriceReadRepository {
+	return &subscriptionPriceReadRepo{db: db}
+}
+
+func (r *subscriptionPriceReadRepo) GetBySubscriptionID(ctx context.Context, subscriptionID uint) ([]model.SubscriptionPrice, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionPriceReadRepo] GetBySubscriptionID called | subscriptionID=%d", subscriptionID)
+
+	var prices []model.SubscriptionPrice
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("effective_from").
+		Find(&prices).Error
+	if err != nil {
+		log.Errorf("[SubscriptionPriceReadRepo] GetBySubscriptionID error | subscriptionID=%d err=%v", subscriptionID, err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionPriceReadRepo] GetBySubscriptionID success | subscriptionID=%d count=%d", subscriptionID, len(prices))
+	return prices, nil
+}
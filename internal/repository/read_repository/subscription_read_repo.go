@@ -2,19 +2,16 @@ package read_repository
 
 import (
 	"context"
-	"time"
 	"github.com/winnamu6/go-subscription-service/internal/model"
+	"time"
 )
 
 type SubscriptionReadRepository interface {
 	GetByID(ctx context.Context, id uint) (*model.Subscription, error)
+	GetDeletedByID(ctx context.Context, id uint) (*model.Subscription, error)
 	GetByUserID(ctx context.Context, userID string) ([]model.Subscription, error)
-	GetAll(ctx context.Context) ([]model.Subscription, error)
-	SumPriceByFilter(
-		ctx context.Context,
-		userID *string,
-		serviceName *string,
-		startDate time.Time,
-		endDate time.Time,
-	) (float64, error)
+	Exists(ctx context.Context, filter model.SubscriptionFilter, startDate time.Time) (bool, error)
+	GetAll(ctx context.Context, query model.SubscriptionListQuery) (*model.SubscriptionPage, error)
+	Stream(ctx context.Context, query model.SubscriptionListQuery, fn func(sub *model.Subscription) error) error
+	GetOverlapping(ctx context.Context, filter model.SubscriptionFilter, from, to time.Time) ([]model.Subscription, error)
 }
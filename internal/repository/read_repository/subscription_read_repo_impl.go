@@ -3,9 +3,11 @@ package read_repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
 	"github.com/winnamu6/go-subscription-service/internal/model"
 	"gorm.io/gorm"
@@ -28,7 +30,7 @@ func (r *subscriptionReadRepo) GetByID(ctx context.Context, id uint) (*model.Sub
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Warnf("[SubscriptionReadRepo] GetByID not found | id=%d", id)
-			return nil, nil
+			return nil, model.ErrSubscriptionNotFound
 		}
 		log.Errorf("[SubscriptionReadRepo] GetByID error | id=%d err=%v", id, err)
 		return nil, err
@@ -38,6 +40,27 @@ func (r *subscriptionReadRepo) GetByID(ctx context.Context, id uint) (*model.Sub
 	return &sub, nil
 }
 
+// GetDeletedByID returns a soft-deleted subscription, or
+// model.ErrDeletedSubscriptionNotFound if there is none with this id.
+func (r *subscriptionReadRepo) GetDeletedByID(ctx context.Context, id uint) (*model.Subscription, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionReadRepo] GetDeletedByID called | id=%d", id)
+
+	var sub model.Subscription
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&sub, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[SubscriptionReadRepo] GetDeletedByID not found | id=%d", id)
+			return nil, model.ErrDeletedSubscriptionNotFound
+		}
+		log.Errorf("[SubscriptionReadRepo] GetDeletedByID error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionReadRepo] GetDeletedByID success | id=%d", id)
+	return &sub, nil
+}
+
 func (r *subscriptionReadRepo) GetByUserID(ctx context.Context, userID string) ([]model.Subscription, error) {
 	log := logger.Get()
 	log.Infof("[SubscriptionReadRepo] GetByUserID called | userID=%s", userID)
@@ -45,7 +68,7 @@ func (r *subscriptionReadRepo) GetByUserID(ctx context.Context, userID string) (
 	uid, err := uuid.Parse(userID)
 	if err != nil {
 		log.Warnf("[SubscriptionReadRepo] GetByUserID invalid UUID | userID=%s err=%v", userID, err)
-		return nil, err
+		return nil, domainerr.Wrap(err, domainerr.KindValidation, model.ErrInvalidUserID.Code, model.ErrInvalidUserID.Message)
 	}
 
 	var subs []model.Subscription
@@ -59,55 +82,218 @@ func (r *subscriptionReadRepo) GetByUserID(ctx context.Context, userID string) (
 	return subs, nil
 }
 
-func (r *subscriptionReadRepo) GetAll(ctx context.Context) ([]model.Subscription, error) {
+// Exists reports whether a subscription selected by filter starts at
+// startDate. Import uses it to skip rows that were loaded before.
+func (r *subscriptionReadRepo) Exists(ctx context.Context, filter model.SubscriptionFilter, startDate time.Time) (bool, error) {
 	log := logger.Get()
-	log.Info("[SubscriptionReadRepo] GetAll called")
+	log.Infof("[SubscriptionReadRepo] Exists called | start=%s", startDate.Format(time.RFC3339))
+
+	var count int64
+	err := applyFilter(r.db.WithContext(ctx).Model(&model.Subscription{}), filter).
+		Where("start_date = ?", startDate).
+		Count(&count).Error
+	if err != nil {
+		log.Errorf("[SubscriptionReadRepo] Exists error | err=%v", err)
+		return false, err
+	}
+
+	log.Infof("[SubscriptionReadRepo] Exists success | exists=%t", count > 0)
+	return count > 0, nil
+}
+
+func (r *subscriptionReadRepo) GetAll(ctx context.Context, query model.SubscriptionListQuery) (*model.SubscriptionPage, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionReadRepo] GetAll called | sort=%s order=%s limit=%d offset=%d cursor=%t",
+		query.Sort, query.Order, query.Limit, query.Offset, query.Cursor != "")
+
+	base, sortField, order, err := r.listQuery(ctx, query)
+	if err != nil {
+		log.Warnf("[SubscriptionReadRepo] GetAll unsupported sort | sort=%s", query.Sort)
+		return nil, err
+	}
+	column := sortColumns[sortField]
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		log.Errorf("[SubscriptionReadRepo] GetAll count error | err=%v", err)
+		return nil, err
+	}
+
+	q := base.Session(&gorm.Session{})
+	if query.Cursor != "" {
+		c, err := decodeCursor(query.Cursor)
+		if err != nil || c.Sort != sortField || c.Order != order {
+			log.Warnf("[SubscriptionReadRepo] GetAll invalid cursor | cursor=%s", query.Cursor)
+			return nil, model.ErrInvalidCursor
+		}
+		cmp := ">"
+		if order == model.SortDesc {
+			cmp = "<"
+		}
+		q = q.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, cmp), c.Value, c.ID)
+	} else if query.Offset > 0 {
+		q = q.Offset(query.Offset)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = model.DefaultPageLimit
+	}
+
+	if query.WithHistory {
+		q = q.Preload("Prices").Preload("Pauses")
+	}
 
 	var subs []model.Subscription
-	err := r.db.WithContext(ctx).Find(&subs).Error
+	err = q.Order(fmt.Sprintf("%s %s, id %s", column, order, order)).Limit(limit + 1).Find(&subs).Error
 	if err != nil {
 		log.Errorf("[SubscriptionReadRepo] GetAll error | err=%v", err)
 		return nil, err
 	}
 
-	log.Infof("[SubscriptionReadRepo] GetAll success | count=%d", len(subs))
-	return subs, nil
+	page := &model.SubscriptionPage{Items: subs, Total: total}
+	if len(subs) > limit {
+		page.Items = subs[:limit]
+		last := &page.Items[limit-1]
+		page.NextCursor = encodeCursor(cursor{
+			Sort:  sortField,
+			Order: order,
+			Value: sortValue(last, sortField),
+			ID:    last.ID,
+		})
+	}
+
+	log.Infof("[SubscriptionReadRepo] GetAll success | count=%d total=%d", len(page.Items), total)
+	return page, nil
 }
 
-func (r *subscriptionReadRepo) SumPriceByFilter(
-	ctx context.Context,
-	userID *string,
-	serviceName *string,
-	startDate, endDate time.Time,
-) (float64, error) {
+// Stream calls fn for every subscription matching the filters and sorting of
+// query, reading rows one at a time. Pagination fields are ignored.
+func (r *subscriptionReadRepo) Stream(ctx context.Context, query model.SubscriptionListQuery, fn func(sub *model.Subscription) error) error {
 	log := logger.Get()
-	log.Infof("[SubscriptionReadRepo] SumPriceByFilter called | userID=%v serviceName=%v start=%s end=%s",
-		userID, serviceName, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
+	log.Infof("[SubscriptionReadRepo] Stream called | sort=%s order=%s", query.Sort, query.Order)
 
-	query := r.db.WithContext(ctx).Model(&model.Subscription{}).Select("SUM(price) as total_price")
+	base, sortField, order, err := r.listQuery(ctx, query)
+	if err != nil {
+		log.Warnf("[SubscriptionReadRepo] Stream unsupported sort | sort=%s", query.Sort)
+		return err
+	}
 
-	if userID != nil && *userID != "" {
-		uid, err := uuid.Parse(*userID)
-		if err != nil {
-			log.Warnf("[SubscriptionReadRepo] SumPriceByFilter invalid userID=%s err=%v", *userID, err)
-			return 0, err
+	rows, err := base.Order(fmt.Sprintf("%s %s, id %s", sortColumns[sortField], order, order)).Rows()
+	if err != nil {
+		log.Errorf("[SubscriptionReadRepo] Stream error | err=%v", err)
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var sub model.Subscription
+		if err := base.ScanRows(rows, &sub); err != nil {
+			log.Errorf("[SubscriptionReadRepo] Stream scan error | err=%v", err)
+			return err
+		}
+		if err := fn(&sub); err != nil {
+			log.Warnf("[SubscriptionReadRepo] Stream stopped | count=%d err=%v", count, err)
+			return err
 		}
-		query = query.Where("user_id = ?", uid)
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("[SubscriptionReadRepo] Stream error | err=%v", err)
+		return err
+	}
+
+	log.Infof("[SubscriptionReadRepo] Stream success | count=%d", count)
+	return nil
+}
+
+// listQuery builds the filtered query shared by GetAll and Stream and
+// resolves the sort field and order.
+func (r *subscriptionReadRepo) listQuery(ctx context.Context, query model.SubscriptionListQuery) (*gorm.DB, string, string, error) {
+	sortField := query.Sort
+	if sortField == "" {
+		sortField = "id"
+	}
+	if _, ok := sortColumns[sortField]; !ok {
+		return nil, "", "", domainerr.Validation("unsupported_sort_field", fmt.Sprintf("unsupported sort field: %s", sortField))
+	}
+	order := model.SortAsc
+	if query.Order == model.SortDesc {
+		order = model.SortDesc
 	}
 
-	if serviceName != nil && *serviceName != "" {
-		query = query.Where("service_name = ?", *serviceName)
+	base := r.db.WithContext(ctx).Model(&model.Subscription{})
+	if query.IncludeDeleted || query.OnlyDeleted {
+		base = base.Unscoped()
 	}
+	if query.OnlyDeleted {
+		base = base.Where("deleted_at IS NOT NULL")
+	}
+	return applyFilter(base, query.Filter), sortField, order, nil
+}
 
-	query = query.Where("start_date >= ? AND start_date <= ?", startDate, endDate)
+func (r *subscriptionReadRepo) GetOverlapping(ctx context.Context, filter model.SubscriptionFilter, from, to time.Time) ([]model.Subscription, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionReadRepo] GetOverlapping called | from=%s to=%s", from.Format(time.RFC3339), to.Format(time.RFC3339))
 
-	var total float64
-	err := query.Scan(&total).Error
+	var subs []model.Subscription
+	err := applyFilter(r.db.WithContext(ctx).Model(&model.Subscription{}), filter).
+		Where("start_date <= ? AND (end_date IS NULL OR end_date >= ?)", to, from).
+		Preload("Prices").
+		Preload("Pauses").
+		Order("id").
+		Find(&subs).Error
 	if err != nil {
-		log.Errorf("[SubscriptionReadRepo] SumPriceByFilter error | err=%v", err)
-		return 0, err
+		log.Errorf("[SubscriptionReadRepo] GetOverlapping error | err=%v", err)
+		return nil, err
 	}
 
-	log.Infof("[SubscriptionReadRepo] SumPriceByFilter success | total_price=%.2f", total)
-	return total, nil
-}
\ No newline at end of file
+	log.Infof("[SubscriptionReadRepo] GetOverlapping success | count=%d", len(subs))
+	return subs, nil
+}
+
+func applyFilter(query *gorm.DB, f model.SubscriptionFilter) *gorm.DB {
+	if f.ServiceName != nil && *f.ServiceName != "" {
+		query = query.Where("service_name = ?", *f.ServiceName)
+	}
+	if f.ServiceID != nil && len(f.ServiceNames) > 0 {
+		query = query.Where("(service_id = ? OR (service_id IS NULL AND service_name IN ?))", *f.ServiceID, f.ServiceNames)
+	} else if f.ServiceID != nil {
+		query = query.Where("service_id = ?", *f.ServiceID)
+	}
+	if f.UserID != nil {
+		query = query.Where("user_id = ?", *f.UserID)
+	}
+	if f.PriceMin != nil {
+		query = query.Where("price_minor >= ?", *f.PriceMin)
+	}
+	if f.PriceMax != nil {
+		query = query.Where("price_minor <= ?", *f.PriceMax)
+	}
+	if f.StartDateFrom != nil {
+		query = query.Where("start_date >= ?", *f.StartDateFrom)
+	}
+	if f.StartDateTo != nil {
+		query = query.Where("start_date <= ?", *f.StartDateTo)
+	}
+	if f.EndDateFrom != nil {
+		query = query.Where("end_date >= ?", *f.EndDateFrom)
+	}
+	if f.EndDateTo != nil {
+		query = query.Where("end_date <= ?", *f.EndDateTo)
+	}
+	if f.ActiveAt != nil {
+		query = query.Where("start_date <= ? AND (end_date IS NULL OR end_date >= ?)", *f.ActiveAt, *f.ActiveAt)
+	}
+	if f.InTrialAt != nil {
+		query = query.Where("start_date <= ? AND trial_end_date > ?", *f.InTrialAt, *f.InTrialAt)
+	}
+	if f.TrialEndDateFrom != nil {
+		query = query.Where("trial_end_date >= ?", *f.TrialEndDateFrom)
+	}
+	if f.TrialEndDateTo != nil {
+		query = query.Where("trial_end_date <= ?", *f.TrialEndDateTo)
+	}
+	return query
+}
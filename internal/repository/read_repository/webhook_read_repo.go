@@ -0,0 +1,15 @@
+package read_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type WebhookReadRepository interface {
+	ListEndpoints(ctx context.Context) ([]model.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id uint) (*model.WebhookEndpoint, error)
+	EndpointsFor(ctx context.Context, eventType string) ([]model.WebhookEndpoint, error)
+	ListDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) ([]model.WebhookDelivery, int64, error)
+	GetDelivery(ctx context.Context, endpointID, id uint) (*model.WebhookDelivery, error)
+}
@@ -0,0 +1,124 @@
+package read_repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type webhookReadRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookReadRepo(db *gorm.DB) WebhookReadRepository {
+	return &webhookReadRepo{db: db}
+}
+
+func (r *webhookReadRepo) ListEndpoints(ctx context.Context) ([]model.WebhookEndpoint, error) {
+	log := logger.Get()
+	log.Info("[WebhookReadRepo] ListEndpoints called")
+
+	var endpoints []model.WebhookEndpoint
+	if err := r.db.WithContext(ctx).Order("id").Find(&endpoints).Error; err != nil {
+		log.Errorf("[WebhookReadRepo] ListEndpoints error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookReadRepo] ListEndpoints success | count=%d", len(endpoints))
+	return endpoints, nil
+}
+
+func (r *webhookReadRepo) GetEndpoint(ctx context.Context, id uint) (*model.WebhookEndpoint, error) {
+	log := logger.Get()
+	log.Infof("[WebhookReadRepo] GetEndpoint called | id=%d", id)
+
+	var endpoint model.WebhookEndpoint
+	if err := r.db.WithContext(ctx).First(&endpoint, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[WebhookReadRepo] GetEndpoint not found | id=%d", id)
+			return nil, model.ErrWebhookNotFound
+		}
+		log.Errorf("[WebhookReadRepo] GetEndpoint error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookReadRepo] GetEndpoint success | id=%d", id)
+	return &endpoint, nil
+}
+
+// EndpointsFor returns the endpoints subscribed to eventType.
+func (r *webhookReadRepo) EndpointsFor(ctx context.Context, eventType string) ([]model.WebhookEndpoint, error) {
+	log := logger.Get()
+	log.Infof("[WebhookReadRepo] EndpointsFor called | eventType=%s", eventType)
+
+	filter, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []model.WebhookEndpoint
+	if err := r.db.WithContext(ctx).Where("event_types @> ?", string(filter)).Order("id").Find(&endpoints).Error; err != nil {
+		log.Errorf("[WebhookReadRepo] EndpointsFor error | eventType=%s err=%v", eventType, err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookReadRepo] EndpointsFor success | eventType=%s count=%d", eventType, len(endpoints))
+	return endpoints, nil
+}
+
+// ListDeliveries returns deliveries of an endpoint, newest first, together
+// with the total number of matching deliveries.
+func (r *webhookReadRepo) ListDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) ([]model.WebhookDelivery, int64, error) {
+	log := logger.Get()
+	log.Infof("[WebhookReadRepo] ListDeliveries called | filter=%+v", filter)
+
+	q := r.db.WithContext(ctx).Model(&model.WebhookDelivery{}).Where("endpoint_id = ?", filter.EndpointID)
+	if filter.Status != nil {
+		q = q.Where("status = ?", *filter.Status)
+	}
+	if filter.EventType != nil {
+		q = q.Where("event_type = ?", *filter.EventType)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		log.Errorf("[WebhookReadRepo] ListDeliveries count error | err=%v", err)
+		return nil, 0, err
+	}
+
+	var deliveries []model.WebhookDelivery
+	err := q.Order("created_at DESC").Order("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&deliveries).Error
+	if err != nil {
+		log.Errorf("[WebhookReadRepo] ListDeliveries error | err=%v", err)
+		return nil, 0, err
+	}
+
+	log.Infof("[WebhookReadRepo] ListDeliveries success | count=%d total=%d", len(deliveries), total)
+	return deliveries, total, nil
+}
+
+func (r *webhookReadRepo) GetDelivery(ctx context.Context, endpointID, id uint) (*model.WebhookDelivery, error) {
+	log := logger.Get()
+	log.Infof("[WebhookReadRepo] GetDelivery called | endpointID=%d id=%d", endpointID, id)
+
+	var delivery model.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("endpoint_id = ?", endpointID).First(&delivery, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[WebhookReadRepo] GetDelivery not found | endpointID=%d id=%d", endpointID, id)
+			return nil, model.ErrWebhookDeliveryNotFound
+		}
+		log.Errorf("[WebhookReadRepo] GetDelivery error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookReadRepo] GetDelivery success | id=%d", id)
+	return &delivery, nil
+}
@@ -0,0 +1,34 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type auditWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditWriteRepo(db *gorm.DB) AuditWriteRepository {
+	return &auditWriteRepo{db: db}
+}
+
+// Create stores the event using the transaction from ctx, if any, so that the
+// audit entry is committed or rolled back together with the change itself.
+func (r *auditWriteRepo) Create(ctx context.Context, event *model.AuditEvent) error {
+	log := logger.Get()
+	log.Infof("[AuditWriteRepo] Create called | entity=%s id=%d action=%s actor=%s",
+		event.EntityType, event.EntityID, event.Action, event.Actor)
+
+	if err := db.Conn(ctx, r.db).Create(event).Error; err != nil {
+		log.Errorf("[AuditWriteRepo] Create error | entity=%s id=%d err=%v", event.EntityType, event.EntityID, err)
+		return err
+	}
+
+	log.Infof("[AuditWriteRepo] Create success | eventID=%d", event.ID)
+	return nil
+}
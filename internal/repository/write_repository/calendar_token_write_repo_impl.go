@@ -0,0 +1,37 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type calendarTokenWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewCalendarTokenWriteRepo(db *gorm.DB) CalendarTokenWriteRepository {
+	return &calendarTokenWriteRepo{db: db}
+}
+
+// Save stores the token of a user, replacing the previous one.
+func (r *calendarTokenWriteRepo) Save(ctx context.Context, token *model.CalendarToken) error {
+	log := logger.Get()
+	log.Infof("[CalendarTokenWriteRepo] Save called | userID=%s", token.UserID)
+
+	err := db.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(token).Error
+	if err != nil {
+		log.Errorf("[CalendarTokenWriteRepo] Save error | userID=%s err=%v", token.UserID, err)
+		return err
+	}
+
+	log.Infof("[CalendarTokenWriteRepo] Save success | userID=%s", token.UserID)
+	return nil
+}
@@ -0,0 +1,14 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type ChargeWriteRepository interface {
+	TryLockSubscription(ctx context.Context, subscriptionID uint) (bool, error)
+	LastPeriodStart(ctx context.Context, subscriptionID uint) (*time.Time, error)
+	CreateMissing(ctx context.Context, charges []model.Charge) (int64, error)
+}
@@ -0,0 +1,80 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// chargeLockNamespace is the first key of the two-key advisory lock taken per
+// subscription while its charges are materialized.
+const chargeLockNamespace = 7001
+
+type chargeWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewChargeWriteRepo(db *gorm.DB) ChargeWriteRepository {
+	return &chargeWriteRepo{db: db}
+}
+
+// TryLockSubscription takes a transaction level advisory lock for the
+// subscription. It must be called inside WithinTransaction; the lock is
+// released on commit or rollback.
+func (r *chargeWriteRepo) TryLockSubscription(ctx context.Context, subscriptionID uint) (bool, error) {
+	log := logger.Get()
+
+	var locked bool
+	err := db.Conn(ctx, r.db).
+		Raw("SELECT pg_try_advisory_xact_lock(?, ?)", chargeLockNamespace, int32(subscriptionID)).
+		Scan(&locked).Error
+	if err != nil {
+		log.Errorf("[ChargeWriteRepo] TryLockSubscription error | subscriptionID=%d err=%v", subscriptionID, err)
+		return false, err
+	}
+	if !locked {
+		log.Infof("[ChargeWriteRepo] TryLockSubscription busy | subscriptionID=%d", subscriptionID)
+	}
+	return locked, nil
+}
+
+func (r *chargeWriteRepo) LastPeriodStart(ctx context.Context, subscriptionID uint) (*time.Time, error) {
+	log := logger.Get()
+
+	var last *time.Time
+	err := db.Conn(ctx, r.db).
+		Model(&model.Charge{}).
+		Select("MAX(period_start)").
+		Where("subscription_id = ?", subscriptionID).
+		Scan(&last).Error
+	if err != nil {
+		log.Errorf("[ChargeWriteRepo] LastPeriodStart error | subscriptionID=%d err=%v", subscriptionID, err)
+		return nil, err
+	}
+	return last, nil
+}
+
+func (r *chargeWriteRepo) CreateMissing(ctx context.Context, charges []model.Charge) (int64, error) {
+	log := logger.Get()
+	log.Infof("[ChargeWriteRepo] CreateMissing called | count=%d", len(charges))
+
+	if len(charges) == 0 {
+		return 0, nil
+	}
+
+	res := db.Conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&charges)
+	if res.Error != nil {
+		log.Errorf("[ChargeWriteRepo] CreateMissing error | err=%v", res.Error)
+		return 0, res.Error
+	}
+
+	log.Infof("[ChargeWriteRepo] CreateMissing success | created=%d", res.RowsAffected)
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,11 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type ExchangeRateWriteRepository interface {
+	Upsert(ctx context.Context, rates []model.ExchangeRate) error
+}
@@ -0,0 +1,39 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exchangeRateWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateWriteRepo(db *gorm.DB) ExchangeRateWriteRepository {
+	return &exchangeRateWriteRepo{db: db}
+}
+
+func (r *exchangeRateWriteRepo) Upsert(ctx context.Context, rates []model.ExchangeRate) error {
+	log := logger.Get()
+	log.Infof("[ExchangeRateWriteRepo] Upsert called | count=%d", len(rates))
+
+	if len(rates) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "from_currency"}, {Name: "to_currency"}, {Name: "effective_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(&rates).Error
+	if err != nil {
+		log.Errorf("[ExchangeRateWriteRepo] Upsert error | err=%v", err)
+		return err
+	}
+
+	log.Infof("[ExchangeRateWriteRepo] Upsert success | count=%d", len(rates))
+	return nil
+}
@@ -0,0 +1,15 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type IdempotencyKeyWriteRepository interface {
+	Reserve(ctx context.Context, key *model.IdempotencyKey) (bool, error)
+	Get(ctx context.Context, scope, key string) (*model.IdempotencyKey, error)
+	Complete(ctx context.Context, scope, key string, statusCode int, body []byte) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
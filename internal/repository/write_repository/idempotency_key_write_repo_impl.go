@@ -0,0 +1,95 @@
+package write_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type idempotencyKeyWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyWriteRepo(db *gorm.DB) IdempotencyKeyWriteRepository {
+	return &idempotencyKeyWriteRepo{db: db}
+}
+
+// Reserve inserts a pending key. An expired key with the same name is taken
+// over. It reports false if a live key already exists.
+func (r *idempotencyKeyWriteRepo) Reserve(ctx context.Context, key *model.IdempotencyKey) (bool, error) {
+	log := logger.Get()
+	log.Infof("[IdempotencyKeyWriteRepo] Reserve called | scope=%s key=%s", key.Scope, key.Key)
+
+	res := db.Conn(ctx, r.db).Exec(`
+		INSERT INTO idempotency_keys (key, scope, fingerprint, status_code, response_body, created_at, expires_at)
+		VALUES (?, ?, ?, 0, NULL, ?, ?)
+		ON CONFLICT (key, scope) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+		    status_code = 0,
+		    response_body = NULL,
+		    created_at = EXCLUDED.created_at,
+		    expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`,
+		key.Key, key.Scope, key.Fingerprint, key.CreatedAt, key.ExpiresAt)
+	if res.Error != nil {
+		log.Errorf("[IdempotencyKeyWriteRepo] Reserve error | scope=%s key=%s err=%v", key.Scope, key.Key, res.Error)
+		return false, res.Error
+	}
+
+	log.Infof("[IdempotencyKeyWriteRepo] Reserve success | scope=%s key=%s reserved=%t", key.Scope, key.Key, res.RowsAffected > 0)
+	return res.RowsAffected > 0, nil
+}
+
+func (r *idempotencyKeyWriteRepo) Get(ctx context.Context, scope, key string) (*model.IdempotencyKey, error) {
+	log := logger.Get()
+	log.Infof("[IdempotencyKeyWriteRepo] Get called | scope=%s key=%s", scope, key)
+
+	var record model.IdempotencyKey
+	err := db.Conn(ctx, r.db).Where("scope = ? AND key = ?", scope, key).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[IdempotencyKeyWriteRepo] Get not found | scope=%s key=%s", scope, key)
+			return nil, nil
+		}
+		log.Errorf("[IdempotencyKeyWriteRepo] Get error | scope=%s key=%s err=%v", scope, key, err)
+		return nil, err
+	}
+
+	log.Infof("[IdempotencyKeyWriteRepo] Get success | scope=%s key=%s status=%d", scope, key, record.StatusCode)
+	return &record, nil
+}
+
+func (r *idempotencyKeyWriteRepo) Complete(ctx context.Context, scope, key string, statusCode int, body []byte) error {
+	log := logger.Get()
+	log.Infof("[IdempotencyKeyWriteRepo] Complete called | scope=%s key=%s status=%d", scope, key, statusCode)
+
+	err := db.Conn(ctx, r.db).Model(&model.IdempotencyKey{}).
+		Where("scope = ? AND key = ?", scope, key).
+		Updates(map[string]any{"status_code": statusCode, "response_body": body}).Error
+	if err != nil {
+		log.Errorf("[IdempotencyKeyWriteRepo] Complete error | scope=%s key=%s err=%v", scope, key, err)
+		return err
+	}
+
+	log.Infof("[IdempotencyKeyWriteRepo] Complete success | scope=%s key=%s", scope, key)
+	return nil
+}
+
+func (r *idempotencyKeyWriteRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	log := logger.Get()
+	log.Infof("[IdempotencyKeyWriteRepo] DeleteExpired called | now=%s", now.Format(time.RFC3339))
+
+	res := db.Conn(ctx, r.db).Where("expires_at <= ?", now).Delete(&model.IdempotencyKey{})
+	if res.Error != nil {
+		log.Errorf("[IdempotencyKeyWriteRepo] DeleteExpired error | err=%v", res.Error)
+		return 0, res.Error
+	}
+
+	log.Infof("[IdempotencyKeyWriteRepo] DeleteExpired success | deleted=%d", res.RowsAffected)
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,13 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type NotificationWriteRepository interface {
+	SavePreferences(ctx context.Context, pref *model.NotificationPreference) error
+	ClaimReminder(ctx context.Context, reminder *model.SentReminder) (bool, error)
+	ReleaseReminder(ctx context.Context, id uint) error
+}
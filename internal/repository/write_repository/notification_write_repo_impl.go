@@ -0,0 +1,67 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationWriteRepo(db *gorm.DB) NotificationWriteRepository {
+	return &notificationWriteRepo{db: db}
+}
+
+// SavePreferences stores the preferences of a user, replacing previous ones.
+func (r *notificationWriteRepo) SavePreferences(ctx context.Context, pref *model.NotificationPreference) error {
+	log := logger.Get()
+	log.Infof("[NotificationWriteRepo] SavePreferences called | userID=%s", pref.UserID)
+
+	err := db.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"email", "renewal_reminders", "expiration_reminders", "days_before", "updated_at",
+		}),
+	}, clause.Returning{}).Create(pref).Error
+	if err != nil {
+		log.Errorf("[NotificationWriteRepo] SavePreferences error | userID=%s err=%v", pref.UserID, err)
+		return err
+	}
+
+	log.Infof("[NotificationWriteRepo] SavePreferences success | userID=%s", pref.UserID)
+	return nil
+}
+
+// ClaimReminder records the reminder before it is sent. It returns false if
+// the same reminder was already recorded.
+func (r *notificationWriteRepo) ClaimReminder(ctx context.Context, reminder *model.SentReminder) (bool, error) {
+	log := logger.Get()
+	log.Infof("[NotificationWriteRepo] ClaimReminder called | subscriptionID=%d kind=%s due=%s",
+		reminder.SubscriptionID, reminder.Kind, reminder.DueDate.Format("2006-01-02"))
+
+	res := db.Conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	if res.Error != nil {
+		log.Errorf("[NotificationWriteRepo] ClaimReminder error | subscriptionID=%d err=%v", reminder.SubscriptionID, res.Error)
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// ReleaseReminder forgets a claimed reminder that could not be sent, so that
+// the next run tries again.
+func (r *notificationWriteRepo) ReleaseReminder(ctx context.Context, id uint) error {
+	log := logger.Get()
+	log.Infof("[NotificationWriteRepo] ReleaseReminder called | id=%d", id)
+
+	if err := db.Conn(ctx, r.db).Delete(&model.SentReminder{}, id).Error; err != nil {
+		log.Errorf("[NotificationWriteRepo] ReleaseReminder error | id=%d err=%v", id, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type OutboxWriteRepository interface {
+	Create(ctx context.Context, msg *model.OutboxMessage) error
+	TryLock(ctx context.Context) (bool, error)
+	ListPending(ctx context.Context, skipKeys []string, limit int) ([]model.OutboxMessage, error)
+	MarkPublished(ctx context.Context, ids []uint, at time.Time) error
+	MarkFailed(ctx context.Context, id uint, reason string, parkAt *time.Time) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,130 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// outboxLockNamespace is the first key of the advisory lock held by the relay
+// that is currently publishing.
+const outboxLockNamespace = 7002
+
+type outboxWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxWriteRepo(db *gorm.DB) OutboxWriteRepository {
+	return &outboxWriteRepo{db: db}
+}
+
+// Create stores the message using the transaction from ctx, so that it is
+// committed or rolled back together with the change it describes.
+func (r *outboxWriteRepo) Create(ctx context.Context, msg *model.OutboxMessage) error {
+	log := logger.Get()
+	log.Infof("[OutboxWriteRepo] Create called | eventType=%s key=%s", msg.EventType, msg.OrderingKey)
+
+	if err := db.Conn(ctx, r.db).Create(msg).Error; err != nil {
+		log.Errorf("[OutboxWriteRepo] Create error | eventType=%s key=%s err=%v", msg.EventType, msg.OrderingKey, err)
+		return err
+	}
+
+	log.Infof("[OutboxWriteRepo] Create success | id=%d eventID=%s", msg.ID, msg.EventID)
+	return nil
+}
+
+// TryLock takes the transaction level advisory lock of the relay. Only one
+// relay publishes at a time, which keeps messages in order across instances.
+// It must be called inside WithinTransaction.
+func (r *outboxWriteRepo) TryLock(ctx context.Context) (bool, error) {
+	log := logger.Get()
+
+	var locked bool
+	err := db.Conn(ctx, r.db).
+		Raw("SELECT pg_try_advisory_xact_lock(?, 0)", outboxLockNamespace).
+		Scan(&locked).Error
+	if err != nil {
+		log.Errorf("[OutboxWriteRepo] TryLock error | err=%v", err)
+		return false, err
+	}
+	if !locked {
+		log.Info("[OutboxWriteRepo] TryLock busy")
+	}
+	return locked, nil
+}
+
+// ListPending returns up to limit unpublished messages that are not parked,
+// in the order they were written, leaving out those whose ordering key is in
+// skipKeys.
+func (r *outboxWriteRepo) ListPending(ctx context.Context, skipKeys []string, limit int) ([]model.OutboxMessage, error) {
+	log := logger.Get()
+	log.Infof("[OutboxWriteRepo] ListPending called | skipped=%d limit=%d", len(skipKeys), limit)
+
+	q := db.Conn(ctx, r.db).Where("published_at IS NULL AND failed_at IS NULL")
+	if len(skipKeys) > 0 {
+		q = q.Where("ordering_key NOT IN ?", skipKeys)
+	}
+
+	var msgs []model.OutboxMessage
+	if err := q.Order("id").Limit(limit).Find(&msgs).Error; err != nil {
+		log.Errorf("[OutboxWriteRepo] ListPending error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[OutboxWriteRepo] ListPending success | count=%d", len(msgs))
+	return msgs, nil
+}
+
+func (r *outboxWriteRepo) MarkPublished(ctx context.Context, ids []uint, at time.Time) error {
+	log := logger.Get()
+	log.Infof("[OutboxWriteRepo] MarkPublished called | count=%d", len(ids))
+
+	if len(ids) == 0 {
+		return nil
+	}
+	err := db.Conn(ctx, r.db).Model(&model.OutboxMessage{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{"published_at": at, "last_error": ""}).Error
+	if err != nil {
+		log.Errorf("[OutboxWriteRepo] MarkPublished error | err=%v", err)
+		return err
+	}
+
+	log.Infof("[OutboxWriteRepo] MarkPublished success | count=%d", len(ids))
+	return nil
+}
+
+// MarkFailed records a failed publication. The message stays pending unless
+// parkAt is set, in which case it is parked as failed at that time.
+func (r *outboxWriteRepo) MarkFailed(ctx context.Context, id uint, reason string, parkAt *time.Time) error {
+	log := logger.Get()
+	log.Infof("[OutboxWriteRepo] MarkFailed called | id=%d parked=%t", id, parkAt != nil)
+
+	err := db.Conn(ctx, r.db).Model(&model.OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"attempts": gorm.Expr("attempts + 1"), "last_error": reason, "failed_at": parkAt}).Error
+	if err != nil {
+		log.Errorf("[OutboxWriteRepo] MarkFailed error | id=%d err=%v", id, err)
+		return err
+	}
+	return nil
+}
+
+// DeletePublished removes messages published before the given time.
+func (r *outboxWriteRepo) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	log := logger.Get()
+	log.Infof("[OutboxWriteRepo] DeletePublished called | before=%s", before.Format(time.RFC3339))
+
+	res := db.Conn(ctx, r.db).Where("published_at < ?", before).Delete(&model.OutboxMessage{})
+	if res.Error != nil {
+		log.Errorf("[OutboxWriteRepo] DeletePublished error | err=%v", res.Error)
+		return 0, res.Error
+	}
+
+	log.Infof("[OutboxWriteRepo] DeletePublished success | deleted=%d", res.RowsAffected)
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,27 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type ServiceWriteRepository interface {
+	Create(ctx context.Context, svc *model.Service) error
+	// Update replaces the catalog entry. Subscriptions linked to it are
+	// renamed by the caller.
+	Update(ctx context.Context, svc *model.Service) error
+	// Delete removes the catalog entry unless a subscription, including a
+	// soft-deleted one, references it.
+	Delete(ctx context.Context, id uint) error
+	// ListLinked returns the subscriptions linked to the entry, including
+	// soft-deleted ones, and locks them until the transaction ends.
+	ListLinked(ctx context.Context, id uint) ([]model.Subscription, error)
+	// ListMisnamed returns the subscriptions linked to svc under another name,
+	// including soft-deleted ones, and locks them until the transaction ends.
+	ListMisnamed(ctx context.Context, svc *model.Service) ([]model.Subscription, error)
+	// ListUnlinked returns the subscriptions without a service, including
+	// soft-deleted ones, ordered by service_name and locked until the
+	// transaction ends.
+	ListUnlinked(ctx context.Context) ([]model.Subscription, error)
+}
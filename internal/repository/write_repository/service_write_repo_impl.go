@@ -0,0 +1,138 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type serviceWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewServiceWriteRepo(db *gorm.DB) ServiceWriteRepository {
+	return &serviceWriteRepo{db: db}
+}
+
+func (r *serviceWriteRepo) Create(ctx context.Context, svc *model.Service) error {
+	log := logger.Get()
+	log.Infof("[ServiceWriteRepo] Create called | name=%s", svc.Name)
+
+	if err := db.Conn(ctx, r.db).Create(svc).Error; err != nil {
+		log.Errorf("[ServiceWriteRepo] Create error | name=%s err=%v", svc.Name, err)
+		return err
+	}
+
+	log.Infof("[ServiceWriteRepo] Create success | id=%d", svc.ID)
+	return nil
+}
+
+func (r *serviceWriteRepo) Update(ctx context.Context, svc *model.Service) error {
+	log := logger.Get()
+	log.Infof("[ServiceWriteRepo] Update called | id=%d name=%s", svc.ID, svc.Name)
+
+	svc.UpdatedAt = time.Now()
+	res := db.Conn(ctx, r.db).Model(svc).Select("*").Omit("id", "created_at").Updates(svc)
+	if res.Error != nil {
+		log.Errorf("[ServiceWriteRepo] Update error | id=%d err=%v", svc.ID, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Warnf("[ServiceWriteRepo] Update not found | id=%d", svc.ID)
+		return model.ErrServiceNotFound
+	}
+
+	log.Infof("[ServiceWriteRepo] Update success | id=%d", svc.ID)
+	return nil
+}
+
+func (r *serviceWriteRepo) Delete(ctx context.Context, id uint) error {
+	log := logger.Get()
+	log.Infof("[ServiceWriteRepo] Delete called | id=%d", id)
+
+	conn := db.Conn(ctx, r.db)
+	var refs int64
+	if err := conn.Unscoped().Model(&model.Subscription{}).Where("service_id = ?", id).Count(&refs).Error; err != nil {
+		log.Errorf("[ServiceWriteRepo] Delete error | id=%d err=%v", id, err)
+		return err
+	}
+	if refs > 0 {
+		log.Warnf("[ServiceWriteRepo] Delete in use | id=%d subscriptions=%d", id, refs)
+		return model.ErrServiceInUse
+	}
+
+	res := conn.Delete(&model.Service{}, id)
+	if res.Error != nil {
+		log.Errorf("[ServiceWriteRepo] Delete error | id=%d err=%v", id, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Warnf("[ServiceWriteRepo] Delete not found | id=%d", id)
+		return model.ErrServiceNotFound
+	}
+
+	log.Infof("[ServiceWriteRepo] Delete success | id=%d", id)
+	return nil
+}
+
+func (r *serviceWriteRepo) ListLinked(ctx context.Context, id uint) ([]model.Subscription, error) {
+	log := logger.Get()
+	log.Infof("[ServiceWriteRepo] ListLinked called | id=%d", id)
+
+	var subs []model.Subscription
+	err := db.Conn(ctx, r.db).Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("service_id = ?", id).
+		Order("id").
+		Find(&subs).Error
+	if err != nil {
+		log.Errorf("[ServiceWriteRepo] ListLinked error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[ServiceWriteRepo] ListLinked success | id=%d subscriptions=%d", id, len(subs))
+	return subs, nil
+}
+
+func (r *serviceWriteRepo) ListMisnamed(ctx context.Context, svc *model.Service) ([]model.Subscription, error) {
+	log := logger.Get()
+	log.Infof("[ServiceWriteRepo] ListMisnamed called | id=%d name=%s", svc.ID, svc.Name)
+
+	var subs []model.Subscription
+	err := db.Conn(ctx, r.db).Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("service_id = ? AND service_name <> ?", svc.ID, svc.Name).
+		Order("id").
+		Find(&subs).Error
+	if err != nil {
+		log.Errorf("[ServiceWriteRepo] ListMisnamed error | id=%d err=%v", svc.ID, err)
+		return nil, err
+	}
+
+	log.Infof("[ServiceWriteRepo] ListMisnamed success | id=%d subscriptions=%d", svc.ID, len(subs))
+	return subs, nil
+}
+
+func (r *serviceWriteRepo) ListUnlinked(ctx context.Context) ([]model.Subscription, error) {
+	log := logger.Get()
+	log.Info("[ServiceWriteRepo] ListUnlinked called")
+
+	var subs []model.Subscription
+	err := db.Conn(ctx, r.db).Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("service_id IS NULL").
+		Order("service_name, id").
+		Find(&subs).Error
+	if err != nil {
+		log.Errorf("[ServiceWriteRepo] ListUnlinked error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[ServiceWriteRepo] ListUnlinked success | subscriptions=%d", len(subs))
+	return subs, nil
+}
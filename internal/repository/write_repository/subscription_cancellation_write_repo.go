@@ -0,0 +1,13 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type SubscriptionCancellationWriteRepository interface {
+	Create(ctx context.Context, cancellation *model.SubscriptionCancellation) error
+	Revert(ctx context.Context, subscriptionID uint, at time.Time) error
+}
@@ -0,0 +1,52 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type subscriptionCancellationWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionCancellationWriteRepo(db *gorm.DB) SubscriptionCancellationWriteRepository {
+	return &subscriptionCancellationWriteRepo{db: db}
+}
+
+func (r *subscriptionCancellationWriteRepo) Create(ctx context.Context, cancellation *model.SubscriptionCancellation) error {
+	log := logger.Get()
+	log.Infof("[SubscriptionCancellationWriteRepo] Create called | subscriptionID=%d mode=%s reason=%s",
+		cancellation.SubscriptionID, cancellation.Mode, cancellation.Reason)
+
+	if err := db.Conn(ctx, r.db).Create(cancellation).Error; err != nil {
+		log.Errorf("[SubscriptionCancellationWriteRepo] Create error | subscriptionID=%d err=%v", cancellation.SubscriptionID, err)
+		return err
+	}
+
+	log.Infof("[SubscriptionCancellationWriteRepo] Create success | subscriptionID=%d cancellationID=%d",
+		cancellation.SubscriptionID, cancellation.ID)
+	return nil
+}
+
+// Revert marks the cancellations of the subscription that are still in force
+// as reverted at the given time.
+func (r *subscriptionCancellationWriteRepo) Revert(ctx context.Context, subscriptionID uint, at time.Time) error {
+	log := logger.Get()
+	log.Infof("[SubscriptionCancellationWriteRepo] Revert called | subscriptionID=%d", subscriptionID)
+
+	res := db.Conn(ctx, r.db).Model(&model.SubscriptionCancellation{}).
+		Where("subscription_id = ? AND reverted_at IS NULL", subscriptionID).
+		Update("reverted_at", at)
+	if res.Error != nil {
+		log.Errorf("[SubscriptionCancellationWriteRepo] Revert error | subscriptionID=%d err=%v", subscriptionID, res.Error)
+		return res.Error
+	}
+
+	log.Infof("[SubscriptionCancellationWriteRepo] Revert success | subscriptionID=%d reverted=%d", subscriptionID, res.RowsAffected)
+	return nil
+}
@@ -0,0 +1,15 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type SubscriptionPauseWriteRepository interface {
+	Create(ctx context.Context, pause *model.SubscriptionPause) error
+	// End sets the resume date of the pauses of a subscription that cover at
+	// to at and returns how many were ended.
+	End(ctx context.Context, subscriptionID uint, at time.Time) (int64, error)
+}
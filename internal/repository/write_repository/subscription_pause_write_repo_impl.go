@@ -0,0 +1,49 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type subscriptionPauseWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionPauseWriteRepo(db *gorm.DB) SubscriptionPauseWriteRepository {
+	return &subscriptionPauseWriteRepo{db: db}
+}
+
+func (r *subscriptionPauseWriteRepo) Create(ctx context.Context, pause *model.SubscriptionPause) error {
+	log := logger.Get()
+	log.Infof("[SubscriptionPauseWriteRepo] Create called | subscriptionID=%d pausedAt=%s",
+		pause.SubscriptionID, pause.PausedAt.Format(time.RFC3339))
+
+	if err := db.Conn(ctx, r.db).Create(pause).Error; err != nil {
+		log.Errorf("[SubscriptionPauseWriteRepo] Create error | subscriptionID=%d err=%v", pause.SubscriptionID, err)
+		return err
+	}
+
+	log.Infof("[SubscriptionPauseWriteRepo] Create success | subscriptionID=%d pauseID=%d", pause.SubscriptionID, pause.ID)
+	return nil
+}
+
+func (r *subscriptionPauseWriteRepo) End(ctx context.Context, subscriptionID uint, at time.Time) (int64, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionPauseWriteRepo] End called | subscriptionID=%d at=%s", subscriptionID, at.Format(time.RFC3339))
+
+	res := db.Conn(ctx, r.db).Model(&model.SubscriptionPause{}).
+		Where("subscription_id = ? AND paused_at <= ? AND (resume_at IS NULL OR resume_at > ?)", subscriptionID, at, at).
+		Updates(map[string]any{"resume_at": at, "updated_at": time.Now()})
+	if res.Error != nil {
+		log.Errorf("[SubscriptionPauseWriteRepo] End error | subscriptionID=%d err=%v", subscriptionID, res.Error)
+		return 0, res.Error
+	}
+
+	log.Infof("[SubscriptionPauseWriteRepo] End success | subscriptionID=%d ended=%d", subscriptionID, res.RowsAffected)
+	return res.RowsAffected, nil
+}
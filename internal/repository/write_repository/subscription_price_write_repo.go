@@ -0,0 +1,14 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type SubscriptionPriceWriteRepository interface {
+	Upsert(ctx context.Context, price *model.SubscriptionPrice) error
+	GetEffective(ctx context.Context, subscriptionID uint, at time.Time) (*model.SubscriptionPrice, error)
+	ListDue(ctx context.Context, now time.Time) ([]model.SubscriptionPrice, error)
+}
@@ -0,0 +1,87 @@
+package write_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type subscriptionPriceWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionPriceWriteRepo(db *gorm.DB) SubscriptionPriceWriteRepository {
+	return &subscriptionPriceWriteRepo{db: db}
+}
+
+func (r *subscriptionPriceWriteRepo) Upsert(ctx context.Context, price *model.SubscriptionPrice) error {
+	log := logger.Get()
+	log.Infof("[SubscriptionPriceWriteRepo] Upsert called | subscriptionID=%d effectiveFrom=%s",
+		price.SubscriptionID, price.EffectiveFrom.Format(time.RFC3339))
+
+	err := db.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "effective_from"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount_minor", "currency"}),
+	}).Create(price).Error
+	if err != nil {
+		log.Errorf("[SubscriptionPriceWriteRepo] Upsert error | subscriptionID=%d err=%v", price.SubscriptionID, err)
+		return err
+	}
+
+	log.Infof("[SubscriptionPriceWriteRepo] Upsert success | subscriptionID=%d", price.SubscriptionID)
+	return nil
+}
+
+// GetEffective returns the latest history entry that is in effect at the
+// given time, or nil when every entry starts later.
+func (r *subscriptionPriceWriteRepo) GetEffective(ctx context.Context, subscriptionID uint, at time.Time) (*model.SubscriptionPrice, error) {
+	log := logger.Get()
+
+	var price model.SubscriptionPrice
+	err := db.Conn(ctx, r.db).
+		Where("subscription_id = ? AND effective_from <= ?", subscriptionID, at).
+		Order("effective_from DESC").
+		First(&price).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.Errorf("[SubscriptionPriceWriteRepo] GetEffective error | subscriptionID=%d err=%v", subscriptionID, err)
+		return nil, err
+	}
+	return &price, nil
+}
+
+// ListDue returns the history entries in effect at now that differ from the
+// current price of their (not deleted) subscriptions, one per subscription.
+func (r *subscriptionPriceWriteRepo) ListDue(ctx context.Context, now time.Time) ([]model.SubscriptionPrice, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionPriceWriteRepo] ListDue called | now=%s", now.Format(time.RFC3339))
+
+	var due []model.SubscriptionPrice
+	err := db.Conn(ctx, r.db).Raw(`
+		SELECT p.*
+		FROM (
+			SELECT DISTINCT ON (subscription_id) *
+			FROM subscription_prices
+			WHERE effective_from <= ?
+			ORDER BY subscription_id, effective_from DESC
+		) p
+		JOIN subscriptions s ON s.id = p.subscription_id
+		WHERE s.deleted_at IS NULL
+		  AND (s.price_minor <> p.amount_minor OR s.currency <> p.currency)
+		ORDER BY p.subscription_id`, now).Scan(&due).Error
+	if err != nil {
+		log.Errorf("[SubscriptionPriceWriteRepo] ListDue error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionPriceWriteRepo] ListDue success | due=%d", len(due))
+	return due, nil
+}
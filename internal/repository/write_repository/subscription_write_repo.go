@@ -2,11 +2,20 @@ package write_repository
 
 import (
 	"context"
+	"time"
+
 	"github.com/winnamu6/go-subscription-service/internal/model"
 )
 
 type SubscriptionWriteRepository interface {
 	Create(ctx context.Context, sub *model.Subscription) error
 	Update(ctx context.Context, sub *model.Subscription) error
-	Delete(ctx context.Context, id uint) error
-}
\ No newline at end of file
+	SetPause(ctx context.Context, sub *model.Subscription) error
+	SetPrice(ctx context.Context, sub *model.Subscription) error
+	SetService(ctx context.Context, sub *model.Subscription) error
+	Delete(ctx context.Context, id uint, version int) error
+	Restore(ctx context.Context, id uint) (bool, error)
+	PurgeDeleted(ctx context.Context, before time.Time) ([]model.Subscription, error)
+	MarkExpired(ctx context.Context, now time.Time) ([]model.Subscription, error)
+	MarkTrialsEnded(ctx context.Context, now time.Time) ([]model.Subscription, error)
+}
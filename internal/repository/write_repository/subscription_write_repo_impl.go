@@ -4,9 +4,11 @@ import (
 	"context"
 	"time"
 
+	"github.com/winnamu6/go-subscription-service/internal/db"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
 	"github.com/winnamu6/go-subscription-service/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type subscriptionWriteRepo struct {
@@ -28,7 +30,7 @@ func (r *subscriptionWriteRepo) Create(ctx context.Context, sub *model.Subscript
 		log.Warnf("[SubscriptionWriteRepo] Create warning: ID should be zero for new subscription (got %d)", sub.ID)
 	}
 
-	if err := r.db.WithContext(ctx).Create(sub).Error; err != nil {
+	if err := db.Conn(ctx, r.db).Create(sub).Error; err != nil {
 		log.Errorf("[SubscriptionWriteRepo] Create error | userID=%s serviceName=%s err=%v", sub.UserID, sub.ServiceName, err)
 		return err
 	}
@@ -37,30 +39,261 @@ func (r *subscriptionWriteRepo) Create(ctx context.Context, sub *model.Subscript
 	return nil
 }
 
+// Update writes all fields of sub if its version is still sub.Version and
+// increments the version. It returns model.ErrConcurrentUpdate if the row was
+// changed or deleted in the meantime.
 func (r *subscriptionWriteRepo) Update(ctx context.Context, sub *model.Subscription) error {
 	log := logger.Get()
-	log.Infof("[SubscriptionWriteRepo] Update called | subscriptionID=%d", sub.ID)
+	log.Infof("[SubscriptionWriteRepo] Update called | subscriptionID=%d version=%d", sub.ID, sub.Version)
 
+	expected := sub.Version
+	sub.Version = expected + 1
 	sub.UpdatedAt = time.Now()
 
-	if err := r.db.WithContext(ctx).Save(sub).Error; err != nil {
-		log.Errorf("[SubscriptionWriteRepo] Update error | subscriptionID=%d err=%v", sub.ID, err)
-		return err
+	res := db.Conn(ctx, r.db).Model(sub).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("id", "created_at", "deleted_at", "expired_at", "trial_ended_at", "paused_at", "resume_at").
+		Updates(sub)
+	if res.Error != nil {
+		sub.Version = expected
+		log.Errorf("[SubscriptionWriteRepo] Update error | subscriptionID=%d err=%v", sub.ID, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		sub.Version = expected
+		log.Warnf("[SubscriptionWriteRepo] Update version conflict | subscriptionID=%d version=%d", sub.ID, expected)
+		return model.ErrConcurrentUpdate
 	}
 
-	log.Infof("[SubscriptionWriteRepo] Update success | subscriptionID=%d", sub.ID)
+	log.Infof("[SubscriptionWriteRepo] Update success | subscriptionID=%d version=%d", sub.ID, sub.Version)
 	return nil
 }
 
-func (r *subscriptionWriteRepo) Delete(ctx context.Context, id uint) error {
+// SetPause stores sub.PausedAt and sub.ResumeAt if the version of the
+// subscription is still sub.Version and increments the version. It returns
+// model.ErrConcurrentUpdate if the row was changed or deleted in the meantime.
+func (r *subscriptionWriteRepo) SetPause(ctx context.Context, sub *model.Subscription) error {
 	log := logger.Get()
-	log.Infof("[SubscriptionWriteRepo] Delete called | subscriptionID=%d", id)
+	log.Infof("[SubscriptionWriteRepo] SetPause called | subscriptionID=%d version=%d", sub.ID, sub.Version)
 
-	if err := r.db.WithContext(ctx).Delete(&model.Subscription{}, id).Error; err != nil {
-		log.Errorf("[SubscriptionWriteRepo] Delete error | subscriptionID=%d err=%v", id, err)
-		return err
+	now := time.Now()
+	res := db.Conn(ctx, r.db).Model(&model.Subscription{}).
+		Where("id = ? AND version = ?", sub.ID, sub.Version).
+		Updates(map[string]any{
+			"paused_at":  sub.PausedAt,
+			"resume_at":  sub.ResumeAt,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		log.Errorf("[SubscriptionWriteRepo] SetPause error | subscriptionID=%d err=%v", sub.ID, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Warnf("[SubscriptionWriteRepo] SetPause version conflict | subscriptionID=%d version=%d", sub.ID, sub.Version)
+		return model.ErrConcurrentUpdate
+	}
+	sub.Version++
+	sub.UpdatedAt = now
+
+	log.Infof("[SubscriptionWriteRepo] SetPause success | subscriptionID=%d version=%d", sub.ID, sub.Version)
+	return nil
+}
+
+// SetPrice stores sub.Price and sub.Currency if the version of the
+// subscription is still sub.Version and increments the version. It returns
+// model.ErrConcurrentUpdate if the row was changed or deleted in the meantime.
+func (r *subscriptionWriteRepo) SetPrice(ctx context.Context, sub *model.Subscription) error {
+	log := logger.Get()
+	log.Infof("[SubscriptionWriteRepo] SetPrice called | subscriptionID=%d version=%d", sub.ID, sub.Version)
+
+	now := time.Now()
+	res := db.Conn(ctx, r.db).Model(&model.Subscription{}).
+		Where("id = ? AND version = ?", sub.ID, sub.Version).
+		Updates(map[string]any{
+			"price_minor": sub.Price,
+			"currency":    sub.Currency,
+			"version":     gorm.Expr("version + 1"),
+			"updated_at":  now,
+		})
+	if res.Error != nil {
+		log.Errorf("[SubscriptionWriteRepo] SetPrice error | subscriptionID=%d err=%v", sub.ID, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Warnf("[SubscriptionWriteRepo] SetPrice version conflict | subscriptionID=%d version=%d", sub.ID, sub.Version)
+		return model.ErrConcurrentUpdate
+	}
+	sub.Version++
+	sub.UpdatedAt = now
+
+	log.Infof("[SubscriptionWriteRepo] SetPrice success | subscriptionID=%d version=%d", sub.ID, sub.Version)
+	return nil
+}
+
+// SetService stores sub.ServiceID and sub.ServiceName if the version of the
+// subscription is still sub.Version and increments the version. Soft-deleted
+// subscriptions are updated too. It returns model.ErrConcurrentUpdate if the
+// row was changed in the meantime.
+func (r *subscriptionWriteRepo) SetService(ctx context.Context, sub *model.Subscription) error {
+	log := logger.Get()
+	log.Infof("[SubscriptionWriteRepo] SetService called | subscriptionID=%d version=%d serviceName=%s", sub.ID, sub.Version, sub.ServiceName)
+
+	now := time.Now()
+	res := db.Conn(ctx, r.db).Unscoped().Model(&model.Subscription{}).
+		Where("id = ? AND version = ?", sub.ID, sub.Version).
+		Updates(map[string]any{
+			"service_id":   sub.ServiceID,
+			"service_name": sub.ServiceName,
+			"version":      gorm.Expr("version + 1"),
+			"updated_at":   now,
+		})
+	if res.Error != nil {
+		log.Errorf("[SubscriptionWriteRepo] SetService error | subscriptionID=%d err=%v", sub.ID, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Warnf("[SubscriptionWriteRepo] SetService version conflict | subscriptionID=%d version=%d", sub.ID, sub.Version)
+		return model.ErrConcurrentUpdate
+	}
+	sub.Version++
+	sub.UpdatedAt = now
+
+	log.Infof("[SubscriptionWriteRepo] SetService success | subscriptionID=%d version=%d", sub.ID, sub.Version)
+	return nil
+}
+
+// Delete soft-deletes the subscription if its version is still version. It
+// returns model.ErrConcurrentUpdate if the row was changed or deleted in the
+// meantime.
+func (r *subscriptionWriteRepo) Delete(ctx context.Context, id uint, version int) error {
+	log := logger.Get()
+	log.Infof("[SubscriptionWriteRepo] Delete called | subscriptionID=%d version=%d", id, version)
+
+	res := db.Conn(ctx, r.db).Where("version = ?", version).Delete(&model.Subscription{}, id)
+	if res.Error != nil {
+		log.Errorf("[SubscriptionWriteRepo] Delete error | subscriptionID=%d err=%v", id, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Warnf("[SubscriptionWriteRepo] Delete version conflict | subscriptionID=%d version=%d", id, version)
+		return model.ErrConcurrentUpdate
 	}
 
 	log.Infof("[SubscriptionWriteRepo] Delete success | subscriptionID=%d", id)
 	return nil
-}
\ No newline at end of file
+}
+
+// Restore clears deleted_at of a soft-deleted subscription. It reports false
+// if the subscription does not exist or is not deleted.
+func (r *subscriptionWriteRepo) Restore(ctx context.Context, id uint) (bool, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionWriteRepo] Restore called | subscriptionID=%d", id)
+
+	res := db.Conn(ctx, r.db).Unscoped().
+		Model(&model.Subscription{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]any{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		log.Errorf("[SubscriptionWriteRepo] Restore error | subscriptionID=%d err=%v", id, res.Error)
+		return false, res.Error
+	}
+
+	log.Infof("[SubscriptionWriteRepo] Restore success | subscriptionID=%d restored=%t", id, res.RowsAffected > 0)
+	return res.RowsAffected > 0, nil
+}
+
+// PurgeDeleted permanently removes subscriptions soft-deleted before the given
+// time together with their price, pause and cancellation history and charges,
+// and returns the removed rows. It must be called inside WithinTransaction.
+func (r *subscriptionWriteRepo) PurgeDeleted(ctx context.Context, before time.Time) ([]model.Subscription, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionWriteRepo] PurgeDeleted called | before=%s", before.Format(time.RFC3339))
+
+	conn := db.Conn(ctx, r.db)
+	expired := conn.Unscoped().Model(&model.Subscription{}).
+		Select("id").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+
+	if err := conn.Where("subscription_id IN (?)", expired).Delete(&model.SubscriptionPrice{}).Error; err != nil {
+		log.Errorf("[SubscriptionWriteRepo] PurgeDeleted prices error | err=%v", err)
+		return nil, err
+	}
+	if err := conn.Where("subscription_id IN (?)", expired).Delete(&model.Charge{}).Error; err != nil {
+		log.Errorf("[SubscriptionWriteRepo] PurgeDeleted charges error | err=%v", err)
+		return nil, err
+	}
+	if err := conn.Where("subscription_id IN (?)", expired).Delete(&model.SubscriptionPause{}).Error; err != nil {
+		log.Errorf("[SubscriptionWriteRepo] PurgeDeleted pauses error | err=%v", err)
+		return nil, err
+	}
+	if err := conn.Where("subscription_id IN (?)", expired).Delete(&model.SubscriptionCancellation{}).Error; err != nil {
+		log.Errorf("[SubscriptionWriteRepo] PurgeDeleted cancellations error | err=%v", err)
+		return nil, err
+	}
+
+	var purged []model.Subscription
+	err := conn.Unscoped().
+		Clauses(clause.Returning{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&purged).Error
+	if err != nil {
+		log.Errorf("[SubscriptionWriteRepo] PurgeDeleted error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionWriteRepo] PurgeDeleted success | purged=%d", len(purged))
+	return purged, nil
+}
+
+// MarkExpired stamps expired_at on subscriptions that ended before now and
+// returns them. A subscription whose end date was moved past its previous
+// expiry is returned again once the new end date has passed.
+func (r *subscriptionWriteRepo) MarkExpired(ctx context.Context, now time.Time) ([]model.Subscription, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionWriteRepo] MarkExpired called | now=%s", now.Format(time.RFC3339))
+
+	var expired []model.Subscription
+	err := db.Conn(ctx, r.db).
+		Model(&expired).
+		Clauses(clause.Returning{}).
+		Where("end_date < ? AND (expired_at IS NULL OR expired_at < end_date)", now).
+		UpdateColumn("expired_at", now).Error
+	if err != nil {
+		log.Errorf("[SubscriptionWriteRepo] MarkExpired error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionWriteRepo] MarkExpired success | expired=%d", len(expired))
+	return expired, nil
+}
+
+// MarkTrialsEnded stamps trial_ended_at on subscriptions whose trial ended by
+// now and returns them. Subscriptions that end together with their trial are
+// never converted. A trial extended past its previous end is returned again
+// once the new end has passed.
+func (r *subscriptionWriteRepo) MarkTrialsEnded(ctx context.Context, now time.Time) ([]model.Subscription, error) {
+	log := logger.Get()
+	log.Infof("[SubscriptionWriteRepo] MarkTrialsEnded called | now=%s", now.Format(time.RFC3339))
+
+	var ended []model.Subscription
+	err := db.Conn(ctx, r.db).
+		Model(&ended).
+		Clauses(clause.Returning{}).
+		Where("trial_end_date <= ? AND trial_end_date > start_date", now).
+		Where("end_date IS NULL OR end_date > trial_end_date").
+		Where("trial_ended_at IS NULL OR trial_ended_at < trial_end_date").
+		UpdateColumn("trial_ended_at", now).Error
+	if err != nil {
+		log.Errorf("[SubscriptionWriteRepo] MarkTrialsEnded error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[SubscriptionWriteRepo] MarkTrialsEnded success | ended=%d", len(ended))
+	return ended, nil
+}
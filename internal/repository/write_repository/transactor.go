@@ -0,0 +1,28 @@
+package write_repository
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"gorm.io/gorm"
+)
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// WithinTransaction runs fn in a transaction. Nested calls run inside the
+// transaction already open in ctx, using a savepoint.
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.Conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(db.WithTx(ctx, tx))
+	})
+}
@@ -0,0 +1,16 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type WebhookWriteRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *model.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id uint) error
+	CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, delivery *model.WebhookDelivery) error
+}
@@ -0,0 +1,118 @@
+package write_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/db"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookWriteRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookWriteRepo(db *gorm.DB) WebhookWriteRepository {
+	return &webhookWriteRepo{db: db}
+}
+
+func (r *webhookWriteRepo) CreateEndpoint(ctx context.Context, endpoint *model.WebhookEndpoint) error {
+	log := logger.Get()
+	log.Infof("[WebhookWriteRepo] CreateEndpoint called | url=%s", endpoint.URL)
+
+	if err := db.Conn(ctx, r.db).Create(endpoint).Error; err != nil {
+		log.Errorf("[WebhookWriteRepo] CreateEndpoint error | url=%s err=%v", endpoint.URL, err)
+		return err
+	}
+
+	log.Infof("[WebhookWriteRepo] CreateEndpoint success | id=%d", endpoint.ID)
+	return nil
+}
+
+// DeleteEndpoint soft-deletes the endpoint; its pending deliveries fail on
+// their next attempt.
+func (r *webhookWriteRepo) DeleteEndpoint(ctx context.Context, id uint) error {
+	log := logger.Get()
+	log.Infof("[WebhookWriteRepo] DeleteEndpoint called | id=%d", id)
+
+	res := db.Conn(ctx, r.db).Delete(&model.WebhookEndpoint{}, id)
+	if res.Error != nil {
+		log.Errorf("[WebhookWriteRepo] DeleteEndpoint error | id=%d err=%v", id, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Warnf("[WebhookWriteRepo] DeleteEndpoint not found | id=%d", id)
+		return model.ErrWebhookNotFound
+	}
+
+	log.Infof("[WebhookWriteRepo] DeleteEndpoint success | id=%d", id)
+	return nil
+}
+
+// CreateDeliveries stores deliveries using the transaction from ctx, if any.
+// A delivery of an event already queued for the endpoint is skipped, unless
+// it is a replay.
+func (r *webhookWriteRepo) CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	log := logger.Get()
+	log.Infof("[WebhookWriteRepo] CreateDeliveries called | count=%d", len(deliveries))
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := db.Conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+		log.Errorf("[WebhookWriteRepo] CreateDeliveries error | err=%v", err)
+		return err
+	}
+
+	log.Infof("[WebhookWriteRepo] CreateDeliveries success | count=%d", len(deliveries))
+	return nil
+}
+
+// ClaimDue picks up to limit pending deliveries due at now and postpones
+// them by lease, so that other workers skip them while they are being sent.
+// A delivery whose worker dies is picked up again once the lease expires.
+func (r *webhookWriteRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	log := logger.Get()
+	log.Infof("[WebhookWriteRepo] ClaimDue called | now=%s limit=%d", now.Format(time.RFC3339), limit)
+
+	var deliveries []model.WebhookDelivery
+	err := db.Conn(ctx, r.db).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, model.WebhookDeliveryPending, now, limit,
+	).Scan(&deliveries).Error
+	if err != nil {
+		log.Errorf("[WebhookWriteRepo] ClaimDue error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookWriteRepo] ClaimDue success | claimed=%d", len(deliveries))
+	return deliveries, nil
+}
+
+// SaveAttempt stores the outcome of a delivery attempt.
+func (r *webhookWriteRepo) SaveAttempt(ctx context.Context, delivery *model.WebhookDelivery) error {
+	log := logger.Get()
+	log.Infof("[WebhookWriteRepo] SaveAttempt called | id=%d status=%s attempts=%d", delivery.ID, delivery.Status, delivery.Attempts)
+
+	err := db.Conn(ctx, r.db).Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "last_error", "updated_at").
+		Updates(delivery).Error
+	if err != nil {
+		log.Errorf("[WebhookWriteRepo] SaveAttempt error | id=%d err=%v", delivery.ID, err)
+		return err
+	}
+
+	log.Infof("[WebhookWriteRepo] SaveAttempt success | id=%d", delivery.ID)
+	return nil
+}
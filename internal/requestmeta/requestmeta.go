@@ -0,0 +1,46 @@
+package requestmeta
+
+import "context"
+
+const (
+	// AnonymousActor issues requests that carry no credentials.
+	AnonymousActor = "anonymous"
+	// AdminActor issues requests authenticated with the admin token.
+	AdminActor = "admin"
+	// SystemActor makes changes outside of an HTTP request, e.g. the worker.
+	SystemActor = "system"
+)
+
+// Meta describes who issued the current request. It is attached to the
+// request context by middleware.RequestMeta and read by the audit trail.
+// Actor is only ever set from verified credentials; ActorHint is what the
+// client claims to be and is recorded as is.
+type Meta struct {
+	RequestID string
+	Actor     string
+	ActorHint string
+	SourceIP  string
+}
+
+type metaKey struct{}
+
+func With(ctx context.Context, m Meta) context.Context {
+	return context.WithValue(ctx, metaKey{}, m)
+}
+
+// WithActor returns a copy of ctx whose metadata names actor as the verified
+// issuer of the request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	m := From(ctx)
+	m.Actor = actor
+	return With(ctx, m)
+}
+
+// From returns the metadata stored in ctx. Calls outside of an HTTP request
+// (e.g. from the worker) get the system actor.
+func From(ctx context.Context) Meta {
+	if m, ok := ctx.Value(metaKey{}).(Meta); ok {
+		return m
+	}
+	return Meta{Actor: SystemActor}
+}
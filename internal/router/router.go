@@ -2,19 +2,49 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/winnamu6/go-subscription-service/internal/config"
 	"github.com/winnamu6/go-subscription-service/internal/handler"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/middleware"
 	"github.com/winnamu6/go-subscription-service/internal/service"
 )
 
-func NewRouter(readSvc service.SubscriptionQueryService, writeSvc service.SubscriptionCommandService) *gin.Engine {
+type Services struct {
+	SubscriptionQuery   service.SubscriptionQueryService
+	SubscriptionCommand service.SubscriptionCommandService
+	SubscriptionImport  service.SubscriptionImportService
+	ExchangeRateQuery   service.ExchangeRateQueryService
+	ExchangeRateCommand service.ExchangeRateCommandService
+	ChargeQuery         service.ChargeQueryService
+	AuditQuery          service.AuditQueryService
+	Idempotency         service.IdempotencyService
+	Calendar            service.CalendarService
+	Webhooks            service.WebhookService
+	Notifications       service.NotificationService
+	CancellationQuery   service.CancellationQueryService
+	Catalog             service.CatalogService
+}
+
+func NewRouter(cfg *config.Config, svc Services) *gin.Engine {
 	log := logger.Get()
 	log.Info("[Router] Initializing routes...")
 
+	handler.UseRequestFieldNames()
+
 	r := gin.Default()
+	r.Use(middleware.RequestMeta(), middleware.ErrorHandler())
+	r.NoRoute(middleware.NotFound())
 
-	readHandler := handler.NewSubscriptionReadHandler(readSvc)
-	writeHandler := handler.NewSubscriptionWriteHandler(writeSvc)
+	readHandler := handler.NewSubscriptionReadHandler(svc.SubscriptionQuery)
+	writeHandler := handler.NewSubscriptionWriteHandler(svc.SubscriptionCommand, svc.SubscriptionImport, svc.Idempotency, cfg.DeletedRetention)
+	rateHandler := handler.NewExchangeRateHandler(svc.ExchangeRateQuery, svc.ExchangeRateCommand)
+	chargeHandler := handler.NewChargeHandler(svc.ChargeQuery)
+	auditHandler := handler.NewAuditHandler(svc.AuditQuery)
+	calendarHandler := handler.NewCalendarHandler(svc.Calendar)
+	webhookHandler := handler.NewWebhookHandler(svc.Webhooks)
+	notificationHandler := handler.NewNotificationHandler(svc.Notifications)
+	cancellationHandler := handler.NewCancellationHandler(svc.CancellationQuery)
+	catalogHandler := handler.NewCatalogHandler(svc.Catalog)
 
 	subscriptions := r.Group("/subscriptions")
 	{
@@ -22,10 +52,53 @@ func NewRouter(readSvc service.SubscriptionQueryService, writeSvc service.Subscr
 		subscriptions.GET("/:id", readHandler.GetByID)
 		subscriptions.GET("/user/:user_id", readHandler.GetByUserID)
 		subscriptions.GET("/sum", readHandler.SumPriceByFilter)
+		subscriptions.GET("/breakdown", readHandler.Breakdown)
+		subscriptions.GET("/deleted", readHandler.GetDeleted)
+		subscriptions.GET("/export", readHandler.Export)
+		subscriptions.GET("/churn", cancellationHandler.Churn)
+		subscriptions.GET("/:id/charges", chargeHandler.GetBySubscriptionID)
+		subscriptions.GET("/:id/prices", readHandler.GetPriceHistory)
+		subscriptions.GET("/:id/pauses", readHandler.GetPauseHistory)
+		subscriptions.GET("/:id/history", auditHandler.GetSubscriptionHistory)
 
 		subscriptions.POST("", writeHandler.Create)
+		subscriptions.POST("/import", writeHandler.Import)
 		subscriptions.PUT("/:id", writeHandler.Update)
+		subscriptions.PATCH("/:id", writeHandler.Patch)
 		subscriptions.DELETE("/:id", writeHandler.Delete)
+		subscriptions.POST("/:id/restore", writeHandler.Restore)
+		subscriptions.POST("/:id/pause", writeHandler.Pause)
+		subscriptions.POST("/:id/resume", writeHandler.Resume)
+		subscriptions.POST("/:id/cancel", writeHandler.Cancel)
+	}
+
+	r.GET("/exchange-rates", rateHandler.GetAll)
+	r.GET("/services", catalogHandler.List)
+	r.GET("/services/:id", catalogHandler.Get)
+	r.GET("/users/:user_id/calendar.ics", calendarHandler.Feed)
+
+	admin := r.Group("/admin", middleware.AdminOnly(cfg.AdminToken))
+	{
+		admin.POST("/exchange-rates", rateHandler.Upsert)
+		admin.POST("/exchange-rates/import", rateHandler.ImportCSV)
+		admin.GET("/audit-events", auditHandler.List)
+		admin.POST("/subscriptions/purge", writeHandler.PurgeDeleted)
+		admin.POST("/users/:user_id/calendar-token", calendarHandler.IssueToken)
+		admin.GET("/users/:user_id/notification-preferences", notificationHandler.GetPreferences)
+		admin.PUT("/users/:user_id/notification-preferences", notificationHandler.UpdatePreferences)
+
+		admin.POST("/services", catalogHandler.Create)
+		admin.PUT("/services/:id", catalogHandler.Update)
+		admin.DELETE("/services/:id", catalogHandler.Delete)
+		admin.POST("/services/match", catalogHandler.Match)
+		admin.POST("/services/:id/merge", catalogHandler.Merge)
+
+		admin.POST("/webhooks", webhookHandler.Create)
+		admin.GET("/webhooks", webhookHandler.List)
+		admin.GET("/webhooks/:id", webhookHandler.Get)
+		admin.DELETE("/webhooks/:id", webhookHandler.Delete)
+		admin.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+		admin.POST("/webhooks/:id/deliveries/:delivery_id/replay", webhookHandler.Replay)
 	}
 
 	log.Info("[Router] Routes initialized successfully")
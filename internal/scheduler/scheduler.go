@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+)
+
+type Job func(ctx context.Context, now time.Time) error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// Scheduler runs registered jobs periodically until its context is
+// cancelled. Each job runs once immediately and then every interval; runs of
+// the same job never overlap.
+type Scheduler struct {
+	entries []entry
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+func (s *Scheduler) Every(interval time.Duration, name string, job Job) {
+	s.entries = append(s.entries, entry{name: name, interval: interval, job: job})
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	log := logger.Get()
+	log.Infof("[Scheduler] Starting %d jobs", len(s.entries))
+
+	var wg sync.WaitGroup
+	for _, e := range s.entries {
+		wg.Add(1)
+		go func(e entry) {
+			defer wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+	wg.Wait()
+
+	log.Info("[Scheduler] Stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	log := logger.Get()
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		started := time.Now()
+		if err := e.job(ctx, started); err != nil {
+			log.Errorf("[Scheduler] Job %s failed | err=%v", e.name, err)
+		} else {
+			log.Infof("[Scheduler] Job %s finished | took=%s", e.name, time.Since(started))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
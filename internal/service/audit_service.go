@@ -0,0 +1,159 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+	"github.com/winnamu6/go-subscription-service/internal/requestmeta"
+)
+
+type AuditQueryService interface {
+	List(ctx context.Context, filter model.AuditFilter) (*model.AuditEventListResponse, error)
+	// History returns the public change history of a subscription.
+	History(ctx context.Context, subscriptionID uint, limit, offset int) (*model.SubscriptionHistoryResponse, error)
+}
+
+type auditQueryService struct {
+	readRepo read_repository.AuditReadRepository
+}
+
+func NewAuditQueryService(readRepo read_repository.AuditReadRepository) AuditQueryService {
+	return &auditQueryService{readRepo: readRepo}
+}
+
+func (s *auditQueryService) List(ctx context.Context, filter model.AuditFilter) (*model.AuditEventListResponse, error) {
+	log := logger.Get()
+	log.Infof("[AuditQueryService] List called | filter=%+v", filter)
+
+	if filter.Limit <= 0 {
+		filter.Limit = model.DefaultPageLimit
+	}
+
+	events, total, err := s.readRepo.List(ctx, filter)
+	if err != nil {
+		log.Errorf("[AuditQueryService] List error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[AuditQueryService] List success | count=%d total=%d", len(events), total)
+	return &model.AuditEventListResponse{
+		Items:  events,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+func (s *auditQueryService) History(ctx context.Context, subscriptionID uint, limit, offset int) (*model.SubscriptionHistoryResponse, error) {
+	entityType := model.AuditEntitySubscription
+	res, err := s.List(ctx, model.AuditFilter{
+		EntityType: &entityType,
+		EntityID:   &subscriptionID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.SubscriptionHistoryEntry, len(res.Items))
+	for i, e := range res.Items {
+		items[i] = model.SubscriptionHistoryEntry{
+			ID:        e.ID,
+			Action:    e.Action,
+			Actor:     e.Actor,
+			Before:    e.Before,
+			After:     e.After,
+			Changes:   e.Changes,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+	return &model.SubscriptionHistoryResponse{
+		Items:  items,
+		Total:  res.Total,
+		Limit:  res.Limit,
+		Offset: res.Offset,
+	}, nil
+}
+
+// auditIgnoredFields are bookkeeping fields that change on every write and
+// are left out of the diff.
+var auditIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// recordAudit writes an audit event for a change of an entity. before is nil
+// for creations and after is nil for deletions. It must be called within the
+// same transaction as the change.
+func recordAudit(ctx context.Context, repo write_repository.AuditWriteRepository, entityType string, entityID uint, action string, before, after any) error {
+	meta := requestmeta.From(ctx)
+
+	beforeJSON, beforeMap, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, afterMap, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+	changes, err := json.Marshal(auditDiff(beforeMap, afterMap))
+	if err != nil {
+		return err
+	}
+
+	return repo.Create(ctx, &model.AuditEvent{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      meta.Actor,
+		ActorHint:  meta.ActorHint,
+		RequestID:  meta.RequestID,
+		SourceIP:   meta.SourceIP,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Changes:    changes,
+	})
+}
+
+func auditSnapshot(v any) (json.RawMessage, map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, nil, err
+	}
+	return raw, fields, nil
+}
+
+type auditChange struct {
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+func auditDiff(before, after map[string]json.RawMessage) map[string]auditChange {
+	diff := make(map[string]auditChange)
+	for field, old := range before {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		if cur, ok := after[field]; !ok || !bytes.Equal(old, cur) {
+			diff[field] = auditChange{From: old, To: after[field]}
+		}
+	}
+	for field, cur := range after {
+		if _, ok := before[field]; !ok && !auditIgnoredFields[field] {
+			diff[field] = auditChange{To: cur}
+		}
+	}
+	return diff
+}
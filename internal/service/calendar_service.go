@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/calendar"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+type CalendarService interface {
+	// IssueToken creates a new feed token for the user, revoking the
+	// previous one. The token is only returned here.
+	IssueToken(ctx context.Context, userID uuid.UUID) (*model.CalendarTokenResponse, error)
+	// Feed renders the iCalendar feed of the user if token is valid.
+	Feed(ctx context.Context, userID uuid.UUID, token string) ([]byte, error)
+}
+
+type calendarService struct {
+	readRepo  read_repository.CalendarTokenReadRepository
+	writeRepo write_repository.CalendarTokenWriteRepository
+	subSvc    SubscriptionQueryService
+}
+
+func NewCalendarService(
+	readRepo read_repository.CalendarTokenReadRepository,
+	writeRepo write_repository.CalendarTokenWriteRepository,
+	subSvc SubscriptionQueryService,
+) CalendarService {
+	return &calendarService{readRepo: readRepo, writeRepo: writeRepo, subSvc: subSvc}
+}
+
+func (s *calendarService) IssueToken(ctx context.Context, userID uuid.UUID) (*model.CalendarTokenResponse, error) {
+	log := logger.Get()
+	log.Infof("[CalendarService] IssueToken called | userID=%s", userID)
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Errorf("[CalendarService] IssueToken error | userID=%s err=%v", userID, err)
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	err := s.writeRepo.Save(ctx, &model.CalendarToken{
+		UserID:    userID,
+		TokenHash: hashCalendarToken(token),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Errorf("[CalendarService] IssueToken error | userID=%s err=%v", userID, err)
+		return nil, err
+	}
+
+	log.Infof("[CalendarService] IssueToken success | userID=%s", userID)
+	return &model.CalendarTokenResponse{
+		UserID:   userID,
+		Token:    token,
+		FeedPath: fmt.Sprintf("/users/%s/calendar.ics?token=%s", userID, url.QueryEscape(token)),
+	}, nil
+}
+
+func (s *calendarService) Feed(ctx context.Context, userID uuid.UUID, token string) ([]byte, error) {
+	log := logger.Get()
+	log.Infof("[CalendarService] Feed called | userID=%s", userID)
+
+	stored, err := s.readRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, model.ErrCalendarNotFound) {
+			log.Errorf("[CalendarService] Feed error | userID=%s err=%v", userID, err)
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashCalendarToken(token)), []byte(stored.TokenHash)) != 1 {
+		log.Warnf("[CalendarService] Feed invalid token | userID=%s", userID)
+		return nil, model.ErrCalendarNotFound
+	}
+
+	subs, err := s.subSvc.GetByUserID(ctx, userID.String())
+	if err != nil {
+		log.Errorf("[CalendarService] Feed error | userID=%s err=%v", userID, err)
+		return nil, err
+	}
+
+	log.Infof("[CalendarService] Feed success | userID=%s subscriptions=%d", userID, len(subs))
+	return calendar.Feed("Subscriptions", subs), nil
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
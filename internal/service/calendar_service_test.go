@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type fakeCalendarTokens struct {
+	tokens map[uuid.UUID]model.CalendarToken
+}
+
+func (f *fakeCalendarTokens) GetByUserID(_ context.Context, userID uuid.UUID) (*model.CalendarToken, error) {
+	token, ok := f.tokens[userID]
+	if !ok {
+		return nil, model.ErrCalendarNotFound
+	}
+	return &token, nil
+}
+
+func (f *fakeCalendarTokens) Save(_ context.Context, token *model.CalendarToken) error {
+	f.tokens[token.UserID] = *token
+	return nil
+}
+
+// fakeSubscriptionQueries serves GetByUserID; other methods are not used by
+// the calendar service and panic.
+type fakeSubscriptionQueries struct {
+	SubscriptionQueryService
+	subs []model.SubscriptionResponse
+}
+
+func (f *fakeSubscriptionQueries) GetByUserID(context.Context, string) ([]model.SubscriptionResponse, error) {
+	return f.subs, nil
+}
+
+func TestCalendarFeedToken(t *testing.T) {
+	ctx := context.Background()
+	owner, other := uuid.New(), uuid.New()
+
+	tokens := &fakeCalendarTokens{tokens: map[uuid.UUID]model.CalendarToken{}}
+	svc := NewCalendarService(tokens, tokens, &fakeSubscriptionQueries{})
+
+	issued, err := svc.IssueToken(ctx, owner)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+	if stored := tokens.tokens[owner].TokenHash; stored == issued.Token || stored != hashCalendarToken(issued.Token) {
+		t.Fatalf("stored token hash = %q, want the sha256 of the issued token", stored)
+	}
+	if !strings.HasSuffix(issued.FeedPath, "/calendar.ics?token="+issued.Token) {
+		t.Errorf("FeedPath = %q does not carry the token", issued.FeedPath)
+	}
+
+	reissued, err := svc.IssueToken(ctx, owner)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+	if reissued.Token == issued.Token {
+		t.Fatalf("IssueToken() returned the same token twice")
+	}
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		token   string
+		wantErr error
+	}{
+		{name: "current token", userID: owner, token: reissued.Token},
+		{name: "revoked token", userID: owner, token: issued.Token, wantErr: model.ErrCalendarNotFound},
+		{name: "empty token", userID: owner, token: "", wantErr: model.ErrCalendarNotFound},
+		{name: "stored hash as token", userID: owner, token: tokens.tokens[owner].TokenHash, wantErr: model.ErrCalendarNotFound},
+		{name: "token of another user", userID: other, token: reissued.Token, wantErr: model.ErrCalendarNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed, err := svc.Feed(ctx, tt.userID, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Feed() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !strings.HasPrefix(string(feed), "BEGIN:VCALENDAR\r\n") {
+				t.Errorf("Feed() = %q, want a calendar", feed)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+)
+
+type CancellationQueryService interface {
+	// Churn counts cancellations per service and reason.
+	Churn(ctx context.Context, filter model.ChurnFilter) (*model.ChurnResponse, error)
+}
+
+type cancellationQueryService struct {
+	readRepo read_repository.SubscriptionCancellationReadRepository
+}
+
+func NewCancellationQueryService(readRepo read_repository.SubscriptionCancellationReadRepository) CancellationQueryService {
+	return &cancellationQueryService{readRepo: readRepo}
+}
+
+func (s *cancellationQueryService) Churn(ctx context.Context, filter model.ChurnFilter) (*model.ChurnResponse, error) {
+	log := logger.Get()
+	log.Infof("[CancellationQueryService] Churn called | serviceName=%v from=%v to=%v", filter.ServiceName, filter.From, filter.To)
+
+	rows, err := s.readRepo.CountByReason(ctx, filter)
+	if err != nil {
+		log.Errorf("[CancellationQueryService] Churn error | err=%v", err)
+		return nil, err
+	}
+
+	// rows are ordered by service, so each service is a contiguous run
+	res := &model.ChurnResponse{From: filter.From, To: filter.To, Services: []model.ChurnServiceStat{}}
+	for _, row := range rows {
+		n := len(res.Services)
+		if n == 0 || res.Services[n-1].ServiceName != row.ServiceName {
+			res.Services = append(res.Services, model.ChurnServiceStat{ServiceName: row.ServiceName})
+			n++
+		}
+		stat := &res.Services[n-1]
+		stat.Total += row.Count
+		stat.Reasons = append(stat.Reasons, model.ChurnReasonCount{Reason: row.Reason, Count: row.Count})
+		res.Total += row.Count
+	}
+
+	log.Infof("[CancellationQueryService] Churn success | services=%d total=%d", len(res.Services), res.Total)
+	return res, nil
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+type CatalogService interface {
+	List(ctx context.Context) ([]model.Service, error)
+	Get(ctx context.Context, id uint) (*model.Service, error)
+	Create(ctx context.Context, req *model.ServiceRequest) (*model.Service, error)
+	// Update replaces the entry; subscriptions linked to it take the new name
+	// and each rename is audited and published like an update.
+	Update(ctx context.Context, id uint, req *model.ServiceRequest) (*model.Service, error)
+	Delete(ctx context.Context, id uint) error
+	// Merge moves the subscriptions of the entry sourceID to the entry id,
+	// renaming them like Update, adds the name and aliases of the source to
+	// the aliases of the entry and deletes the source.
+	Merge(ctx context.Context, id, sourceID uint) (*model.Service, error)
+	// Resolve returns the entry whose name or alias matches name, or nil.
+	Resolve(ctx context.Context, name string) (*model.Service, error)
+	// LinkSubscriptions links subscriptions without a service to the catalog
+	// by their service_name, auditing and publishing each change like an
+	// update, and reports the names it could not match.
+	LinkSubscriptions(ctx context.Context) (*model.ServiceMatchReport, error)
+}
+
+type catalogService struct {
+	readRepo  read_repository.ServiceReadRepository
+	writeRepo write_repository.ServiceWriteRepository
+	subRepo   write_repository.SubscriptionWriteRepository
+	auditRepo write_repository.AuditWriteRepository
+	outbox    write_repository.OutboxWriteRepository
+	tx        write_repository.Transactor
+}
+
+func NewCatalogService(
+	readRepo read_repository.ServiceReadRepository,
+	writeRepo write_repository.ServiceWriteRepository,
+	subRepo write_repository.SubscriptionWriteRepository,
+	auditRepo write_repository.AuditWriteRepository,
+	outbox write_repository.OutboxWriteRepository,
+	tx write_repository.Transactor,
+) CatalogService {
+	return &catalogService{
+		readRepo:  readRepo,
+		writeRepo: writeRepo,
+		subRepo:   subRepo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		tx:        tx,
+	}
+}
+
+func (s *catalogService) List(ctx context.Context) ([]model.Service, error) {
+	return s.readRepo.List(ctx)
+}
+
+func (s *catalogService) Get(ctx context.Context, id uint) (*model.Service, error) {
+	return s.readRepo.GetByID(ctx, id)
+}
+
+func (s *catalogService) Create(ctx context.Context, req *model.ServiceRequest) (*model.Service, error) {
+	log := logger.Get()
+	log.Infof("[CatalogService] Create called | name=%s aliases=%v", req.Name, req.Aliases)
+
+	svc, err := s.fromRequest(ctx, 0, req)
+	if err != nil {
+		log.Warnf("[CatalogService] Create invalid | name=%s err=%v", req.Name, err)
+		return nil, err
+	}
+	if err := s.writeRepo.Create(ctx, svc); err != nil {
+		log.Errorf("[CatalogService] Create error | name=%s err=%v", req.Name, err)
+		return nil, err
+	}
+
+	log.Infof("[CatalogService] Create success | id=%d", svc.ID)
+	return svc, nil
+}
+
+func (s *catalogService) Update(ctx context.Context, id uint, req *model.ServiceRequest) (*model.Service, error) {
+	log := logger.Get()
+	log.Infof("[CatalogService] Update called | id=%d name=%s aliases=%v", id, req.Name, req.Aliases)
+
+	existing, err := s.readRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := s.fromRequest(ctx, id, req)
+	if err != nil {
+		log.Warnf("[CatalogService] Update invalid | id=%d err=%v", id, err)
+		return nil, err
+	}
+	svc.ID = id
+	svc.CreatedAt = existing.CreatedAt
+
+	renamed := 0
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.writeRepo.Update(ctx, svc); err != nil {
+			return err
+		}
+		subs, err := s.writeRepo.ListMisnamed(ctx, svc)
+		if err != nil {
+			return err
+		}
+		for i := range subs {
+			if err := s.link(ctx, &subs[i], svc); err != nil {
+				return err
+			}
+		}
+		renamed = len(subs)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[CatalogService] Update error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[CatalogService] Update success | id=%d renamed=%d", id, renamed)
+	return svc, nil
+}
+
+func (s *catalogService) Delete(ctx context.Context, id uint) error {
+	return s.writeRepo.Delete(ctx, id)
+}
+
+func (s *catalogService) Merge(ctx context.Context, id, sourceID uint) (*model.Service, error) {
+	log := logger.Get()
+	log.Infof("[CatalogService] Merge called | id=%d sourceID=%d", id, sourceID)
+
+	if id == sourceID {
+		return nil, model.ErrMergeSameService
+	}
+	svc, err := s.readRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.readRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range svc.Names() {
+		seen[model.NormalizeServiceName(name)] = true
+	}
+	for _, name := range source.Names() {
+		if key := model.NormalizeServiceName(name); !seen[key] {
+			seen[key] = true
+			svc.Aliases = append(svc.Aliases, name)
+		}
+	}
+
+	moved := 0
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		subs, err := s.writeRepo.ListLinked(ctx, source.ID)
+		if err != nil {
+			return err
+		}
+		for i := range subs {
+			if err := s.link(ctx, &subs[i], svc); err != nil {
+				return err
+			}
+		}
+		moved = len(subs)
+		if err := s.writeRepo.Delete(ctx, source.ID); err != nil {
+			return err
+		}
+		return s.writeRepo.Update(ctx, svc)
+	})
+	if err != nil {
+		log.Errorf("[CatalogService] Merge error | id=%d sourceID=%d err=%v", id, sourceID, err)
+		return nil, err
+	}
+
+	log.Infof("[CatalogService] Merge success | id=%d sourceID=%d moved=%d", id, sourceID, moved)
+	return svc, nil
+}
+
+func (s *catalogService) Resolve(ctx context.Context, name string) (*model.Service, error) {
+	services, err := s.readRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewServiceMatcher(services).Match(name), nil
+}
+
+func (s *catalogService) LinkSubscriptions(ctx context.Context) (*model.ServiceMatchReport, error) {
+	log := logger.Get()
+	log.Info("[CatalogService] LinkSubscriptions called")
+
+	report := &model.ServiceMatchReport{
+		Matched:   []model.ServiceNameMatch{},
+		Unmatched: []model.UnmatchedServiceName{},
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		services, err := s.readRepo.List(ctx)
+		if err != nil {
+			return err
+		}
+		matcher := model.NewServiceMatcher(services)
+
+		subs, err := s.writeRepo.ListUnlinked(ctx)
+		if err != nil {
+			return err
+		}
+		// subs are ordered by service_name, so every name is one run
+		for i := 0; i < len(subs); {
+			name := subs[i].ServiceName
+			j := i
+			for j < len(subs) && subs[j].ServiceName == name {
+				j++
+			}
+			svc := matcher.Match(name)
+			if svc == nil {
+				report.Unmatched = append(report.Unmatched, model.UnmatchedServiceName{ServiceName: name, Subscriptions: int64(j - i)})
+				i = j
+				continue
+			}
+			report.Matched = append(report.Matched, model.ServiceNameMatch{
+				ServiceName:   name,
+				ServiceID:     svc.ID,
+				CanonicalName: svc.Name,
+				Subscriptions: int64(j - i),
+			})
+			for ; i < j; i++ {
+				if err := s.link(ctx, &subs[i], svc); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[CatalogService] LinkSubscriptions error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[CatalogService] LinkSubscriptions success | matched=%d unmatched=%d", len(report.Matched), len(report.Unmatched))
+	return report, nil
+}
+
+// link points sub at svc under its canonical name and records the change like
+// an update of the subscription. Soft-deleted subscriptions are relinked
+// silently: consumers were already told they are gone. It must be called
+// inside WithinTransaction.
+func (s *catalogService) link(ctx context.Context, sub *model.Subscription, svc *model.Service) error {
+	before := toSubscriptionResponse(sub)
+	serviceID := svc.ID
+	sub.ServiceID = &serviceID
+	sub.ServiceName = svc.Name
+	if err := s.subRepo.SetService(ctx, sub); err != nil {
+		return err
+	}
+	if sub.DeletedAt.Valid {
+		return nil
+	}
+	after := toSubscriptionResponse(sub)
+	if err := recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, sub.ID, model.AuditActionUpdate, before, after); err != nil {
+		return err
+	}
+	return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionUpdated, after)
+}
+
+// fromRequest builds the entry id from req. Aliases are trimmed and
+// deduplicated, and neither the name nor any alias may match another entry.
+func (s *catalogService) fromRequest(ctx context.Context, id uint, req *model.ServiceRequest) (*model.Service, error) {
+	period, interval, err := normalizeBilling(req.DefaultBillingPeriod, req.DefaultBillingInterval)
+	if err != nil {
+		return nil, err
+	}
+	svc := &model.Service{
+		Name:                   strings.TrimSpace(req.Name),
+		Aliases:                []string{},
+		Category:               req.Category,
+		Website:                req.Website,
+		DefaultPrice:           req.DefaultPrice,
+		DefaultCurrency:        defaultString(req.DefaultCurrency, model.DefaultCurrency),
+		DefaultBillingPeriod:   period,
+		DefaultBillingInterval: interval,
+	}
+	seen := map[string]bool{model.NormalizeServiceName(svc.Name): true}
+	for _, alias := range req.Aliases {
+		alias = strings.TrimSpace(alias)
+		if key := model.NormalizeServiceName(alias); !seen[key] {
+			seen[key] = true
+			svc.Aliases = append(svc.Aliases, alias)
+		}
+	}
+
+	services, err := s.readRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	others := services[:0]
+	for _, other := range services {
+		if other.ID != id {
+			others = append(others, other)
+		}
+	}
+	matcher := model.NewServiceMatcher(others)
+	for _, name := range svc.Names() {
+		if matcher.Match(name) != nil {
+			return nil, model.ErrServiceNameTaken
+		}
+	}
+	return svc, nil
+}
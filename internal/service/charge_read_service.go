@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+)
+
+type ChargeQueryService interface {
+	GetBySubscriptionID(ctx context.Context, subscriptionID uint) ([]model.Charge, error)
+}
+
+type chargeQueryService struct {
+	readRepo read_repository.ChargeReadRepository
+}
+
+func NewChargeQueryService(readRepo read_repository.ChargeReadRepository) ChargeQueryService {
+	return &chargeQueryService{readRepo: readRepo}
+}
+
+func (s *chargeQueryService) GetBySubscriptionID(ctx context.Context, subscriptionID uint) ([]model.Charge, error) {
+	log := logger.Get()
+	log.Infof("[ChargeQueryService] GetBySubscriptionID called | subscriptionID=%d", subscriptionID)
+
+	charges, err := s.readRepo.GetBySubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		log.Errorf("[ChargeQueryService] GetBySubscriptionID error | subscriptionID=%d err=%v", subscriptionID, err)
+		return nil, err
+	}
+
+	log.Infof("[ChargeQueryService] GetBySubscriptionID success | subscriptionID=%d count=%d", subscriptionID, len(charges))
+	return charges, nil
+}
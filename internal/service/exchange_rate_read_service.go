@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/billing"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+)
+
+type ExchangeRateQueryService interface {
+	GetAll(ctx context.Context, currency *string) ([]model.ExchangeRate, error)
+	RateTable(ctx context.Context, until time.Time) (*billing.RateTable, error)
+}
+
+type exchangeRateQueryService struct {
+	readRepo read_repository.ExchangeRateReadRepository
+}
+
+func NewExchangeRateQueryService(readRepo read_repository.ExchangeRateReadRepository) ExchangeRateQueryService {
+	return &exchangeRateQueryService{readRepo: readRepo}
+}
+
+func (s *exchangeRateQueryService) GetAll(ctx context.Context, currency *string) ([]model.ExchangeRate, error) {
+	log := logger.Get()
+	log.Infof("[ExchangeRateQueryService] GetAll called | currency=%v", currency)
+
+	rates, err := s.readRepo.GetAll(ctx, currency)
+	if err != nil {
+		log.Errorf("[ExchangeRateQueryService] GetAll error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[ExchangeRateQueryService] GetAll success | count=%d", len(rates))
+	return rates, nil
+}
+
+func (s *exchangeRateQueryService) RateTable(ctx context.Context, until time.Time) (*billing.RateTable, error) {
+	log := logger.Get()
+	log.Infof("[ExchangeRateQueryService] RateTable called | until=%s", until.Format(time.RFC3339))
+
+	rates, err := s.readRepo.GetEffectiveUntil(ctx, until)
+	if err != nil {
+		log.Errorf("[ExchangeRateQueryService] RateTable error | err=%v", err)
+		return nil, err
+	}
+
+	table, err := billing.NewRateTable(rates)
+	if err != nil {
+		log.Errorf("[ExchangeRateQueryService] RateTable build error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[ExchangeRateQueryService] RateTable success | rates=%d", len(rates))
+	return table, nil
+}
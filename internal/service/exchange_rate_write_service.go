@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+type ExchangeRateCommandService interface {
+	Upsert(ctx context.Context, reqs []model.ExchangeRateRequest) ([]model.ExchangeRate, error)
+	ImportCSV(ctx context.Context, r io.Reader) (int, error)
+}
+
+type exchangeRateCommandService struct {
+	writeRepo write_repository.ExchangeRateWriteRepository
+}
+
+func NewExchangeRateCommandService(writeRepo write_repository.ExchangeRateWriteRepository) ExchangeRateCommandService {
+	return &exchangeRateCommandService{writeRepo: writeRepo}
+}
+
+func (s *exchangeRateCommandService) Upsert(ctx context.Context, reqs []model.ExchangeRateRequest) ([]model.ExchangeRate, error) {
+	log := logger.Get()
+	log.Infof("[ExchangeRateCommandService] Upsert called | count=%d", len(reqs))
+
+	rates := make([]model.ExchangeRate, 0, len(reqs))
+	for i, req := range reqs {
+		rate, err := toExchangeRate(req)
+		if err != nil {
+			log.Warnf("[ExchangeRateCommandService] Upsert invalid rate | index=%d err=%v", i, err)
+			return nil, fmt.Errorf("rate #%d: %w", i+1, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := s.writeRepo.Upsert(ctx, rates); err != nil {
+		log.Errorf("[ExchangeRateCommandService] Upsert error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[ExchangeRateCommandService] Upsert success | count=%d", len(rates))
+	return rates, nil
+}
+
+// ImportCSV loads rates from CSV rows "from_currency,to_currency,rate,effective_date".
+// A header row is optional.
+func (s *exchangeRateCommandService) ImportCSV(ctx context.Context, r io.Reader) (int, error) {
+	log := logger.Get()
+	log.Info("[ExchangeRateCommandService] ImportCSV called")
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	var reqs []model.ExchangeRateRequest
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Warnf("[ExchangeRateCommandService] ImportCSV parse error | line=%d err=%v", line, err)
+			return 0, fmt.Errorf("%w: %v", model.ErrInvalidExchangeRate, err)
+		}
+		if line == 1 && strings.EqualFold(record[0], "from_currency") {
+			continue
+		}
+		reqs = append(reqs, model.ExchangeRateRequest{
+			FromCurrency:  strings.ToUpper(record[0]),
+			ToCurrency:    strings.ToUpper(record[1]),
+			Rate:          record[2],
+			EffectiveDate: record[3],
+		})
+	}
+
+	rates, err := s.Upsert(ctx, reqs)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Infof("[ExchangeRateCommandService] ImportCSV success | count=%d", len(rates))
+	return len(rates), nil
+}
+
+func toExchangeRate(req model.ExchangeRateRequest) (model.ExchangeRate, error) {
+	if !model.IsSupportedCurrency(req.FromCurrency) || !model.IsSupportedCurrency(req.ToCurrency) {
+		return model.ExchangeRate{}, fmt.Errorf("%w: unsupported currency pair %s->%s", model.ErrInvalidExchangeRate, req.FromCurrency, req.ToCurrency)
+	}
+	if req.FromCurrency == req.ToCurrency {
+		return model.ExchangeRate{}, fmt.Errorf("%w: from_currency and to_currency must differ", model.ErrInvalidExchangeRate)
+	}
+	if v, ok := new(big.Rat).SetString(req.Rate); !ok || v.Sign() <= 0 {
+		return model.ExchangeRate{}, fmt.Errorf("%w: rate %q", model.ErrInvalidExchangeRate, req.Rate)
+	}
+	date, err := time.Parse(time.DateOnly, req.EffectiveDate)
+	if err != nil {
+		return model.ExchangeRate{}, fmt.Errorf("%w: effective_date %q", model.ErrInvalidExchangeRate, req.EffectiveDate)
+	}
+	return model.ExchangeRate{
+		FromCurrency:  req.FromCurrency,
+		ToCurrency:    req.ToCurrency,
+		Rate:          req.Rate,
+		EffectiveDate: date,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+type IdempotencyService interface {
+	// Do runs fn at most once per key. The key is reserved, fn runs and its
+	// response is stored in one transaction, so the key, the changes made by
+	// fn and the stored response are committed together or not at all; a
+	// concurrent request with the same key waits for that transaction. When
+	// the request was already completed Do returns the stored record with
+	// replayed set and does not call fn. It returns
+	// model.ErrIdempotencyKeyReused when the key belongs to a different
+	// request.
+	Do(ctx context.Context, scope, key, fingerprint string, fn IdempotentFunc) (record *model.IdempotencyKey, replayed bool, err error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// IdempotentFunc processes a request and returns the response to remember.
+type IdempotentFunc func(ctx context.Context) (statusCode int, body []byte, err error)
+
+type idempotencyService struct {
+	repo write_repository.IdempotencyKeyWriteRepository
+	tx   write_repository.Transactor
+	ttl  time.Duration
+}
+
+func NewIdempotencyService(repo write_repository.IdempotencyKeyWriteRepository, tx write_repository.Transactor, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{repo: repo, tx: tx, ttl: ttl}
+}
+
+func (s *idempotencyService) Do(ctx context.Context, scope, key, fingerprint string, fn IdempotentFunc) (*model.IdempotencyKey, bool, error) {
+	log := logger.Get()
+	log.Infof("[IdempotencyService] Do called | scope=%s key=%s", scope, key)
+
+	var (
+		record   *model.IdempotencyKey
+		replayed bool
+	)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		record = &model.IdempotencyKey{
+			Key:         key,
+			Scope:       scope,
+			Fingerprint: fingerprint,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.ttl),
+		}
+		reserved, err := s.repo.Reserve(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		if !reserved {
+			existing, err := s.repo.Get(ctx, scope, key)
+			if err != nil {
+				return err
+			}
+			switch {
+			case existing != nil && existing.Fingerprint != fingerprint:
+				log.Warnf("[IdempotencyService] Do fingerprint mismatch | scope=%s key=%s", scope, key)
+				return model.ErrIdempotencyKeyReused
+			case existing == nil || existing.StatusCode == 0:
+				log.Warnf("[IdempotencyService] Do in progress | scope=%s key=%s", scope, key)
+				return model.ErrIdempotencyKeyInProgress
+			}
+			record, replayed = existing, true
+			return nil
+		}
+
+		if record.StatusCode, record.ResponseBody, err = fn(ctx); err != nil {
+			return err
+		}
+		return s.repo.Complete(ctx, scope, key, record.StatusCode, record.ResponseBody)
+	})
+	if err != nil {
+		log.Errorf("[IdempotencyService] Do error | scope=%s key=%s err=%v", scope, key, err)
+		return nil, false, err
+	}
+
+	log.Infof("[IdempotencyService] Do success | scope=%s key=%s status=%d replayed=%t", scope, key, record.StatusCode, replayed)
+	return record, replayed, nil
+}
+
+func (s *idempotencyService) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.DeleteExpired(ctx, now)
+}
+
+// RequestFingerprint hashes the canonical JSON form of a decoded request, so
+// that retries differing only in whitespace or key order match.
+func RequestFingerprint(req any) (string, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
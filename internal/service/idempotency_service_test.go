@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+type idempotencyID struct{ scope, key string }
+
+// fakeIdempotencyKeys keeps the records in memory. Like the real repository,
+// Reserve takes over an expired key and reports false for a live one.
+type fakeIdempotencyKeys struct {
+	records map[idempotencyID]model.IdempotencyKey
+}
+
+func (f *fakeIdempotencyKeys) Reserve(_ context.Context, key *model.IdempotencyKey) (bool, error) {
+	id := idempotencyID{key.Scope, key.Key}
+	if existing, ok := f.records[id]; ok && existing.ExpiresAt.After(key.CreatedAt) {
+		return false, nil
+	}
+	f.records[id] = *key
+	return true, nil
+}
+
+func (f *fakeIdempotencyKeys) Get(_ context.Context, scope, key string) (*model.IdempotencyKey, error) {
+	record, ok := f.records[idempotencyID{scope, key}]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (f *fakeIdempotencyKeys) Complete(_ context.Context, scope, key string, statusCode int, body []byte) error {
+	id := idempotencyID{scope, key}
+	record := f.records[id]
+	record.StatusCode, record.ResponseBody = statusCode, body
+	f.records[id] = record
+	return nil
+}
+
+func (f *fakeIdempotencyKeys) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	var n int64
+	for id, record := range f.records {
+		if record.ExpiresAt.Before(now) {
+			delete(f.records, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// WithinTransaction rolls the records back when fn fails.
+func (f *fakeIdempotencyKeys) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := maps.Clone(f.records)
+	if err := fn(ctx); err != nil {
+		f.records = saved
+		return err
+	}
+	return nil
+}
+
+func TestIdempotencyDo(t *testing.T) {
+	const scope, key = "subscriptions.create", "key-1"
+	errCreate := errors.New("create failed")
+
+	live := time.Now().Add(time.Hour)
+	completed := model.IdempotencyKey{
+		Key: key, Scope: scope, Fingerprint: "a",
+		StatusCode: http.StatusCreated, ResponseBody: []byte(`{"id":1}`),
+		ExpiresAt: live,
+	}
+	inProgress := model.IdempotencyKey{Key: key, Scope: scope, Fingerprint: "a", ExpiresAt: live}
+	otherScope := completed
+	otherScope.Scope = "subscriptions.import"
+	expired := completed
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name         string
+		existing     []model.IdempotencyKey
+		fingerprint  string
+		fnErr        error
+		wantErr      error
+		wantCalled   bool
+		wantReplayed bool
+		wantStatus   int
+		wantBody     string
+		wantStored   *model.IdempotencyKey
+	}{
+		{
+			name:        "first request runs and is stored",
+			fingerprint: "a",
+			wantCalled:  true, wantStatus: http.StatusCreated, wantBody: `{"id":2}`,
+			wantStored: &model.IdempotencyKey{Fingerprint: "a", StatusCode: http.StatusCreated, ResponseBody: []byte(`{"id":2}`)},
+		},
+		{
+			name:         "completed request is replayed",
+			existing:     []model.IdempotencyKey{completed},
+			fingerprint:  "a",
+			wantReplayed: true, wantStatus: http.StatusCreated, wantBody: `{"id":1}`,
+			wantStored: &completed,
+		},
+		{
+			name:        "key reused with another request",
+			existing:    []model.IdempotencyKey{completed},
+			fingerprint: "b",
+			wantErr:     model.ErrIdempotencyKeyReused,
+			wantStored:  &completed,
+		},
+		{
+			name:        "key reused while in progress",
+			existing:    []model.IdempotencyKey{inProgress},
+			fingerprint: "b",
+			wantErr:     model.ErrIdempotencyKeyReused,
+			wantStored:  &inProgress,
+		},
+		{
+			name:        "same request in progress",
+			existing:    []model.IdempotencyKey{inProgress},
+			fingerprint: "a",
+			wantErr:     model.ErrIdempotencyKeyInProgress,
+			wantStored:  &inProgress,
+		},
+		{
+			name:        "failed request releases the key",
+			fingerprint: "a",
+			fnErr:       errCreate,
+			wantErr:     errCreate,
+			wantCalled:  true,
+		},
+		{
+			name:        "expired key is taken over",
+			existing:    []model.IdempotencyKey{expired},
+			fingerprint: "b",
+			wantCalled:  true, wantStatus: http.StatusCreated, wantBody: `{"id":2}`,
+			wantStored: &model.IdempotencyKey{Fingerprint: "b", StatusCode: http.StatusCreated, ResponseBody: []byte(`{"id":2}`)},
+		},
+		{
+			name:        "failed request restores the expired key",
+			existing:    []model.IdempotencyKey{expired},
+			fingerprint: "b",
+			fnErr:       errCreate,
+			wantErr:     errCreate,
+			wantCalled:  true,
+			wantStored:  &expired,
+		},
+		{
+			name:        "keys are per scope",
+			existing:    []model.IdempotencyKey{otherScope},
+			fingerprint: "b",
+			wantCalled:  true, wantStatus: http.StatusCreated, wantBody: `{"id":2}`,
+			wantStored: &model.IdempotencyKey{Fingerprint: "b", StatusCode: http.StatusCreated, ResponseBody: []byte(`{"id":2}`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeIdempotencyKeys{records: map[idempotencyID]model.IdempotencyKey{}}
+			for _, record := range tt.existing {
+				repo.records[idempotencyID{record.Scope, record.Key}] = record
+			}
+			svc := NewIdempotencyService(repo, repo, time.Hour)
+
+			called := false
+			record, replayed, err := svc.Do(context.Background(), scope, key, tt.fingerprint, func(context.Context) (int, []byte, error) {
+				called = true
+				return http.StatusCreated, []byte(`{"id":2}`), tt.fnErr
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("fn called = %t, want %t", called, tt.wantCalled)
+			}
+			if tt.wantErr == nil {
+				if replayed != tt.wantReplayed || record.StatusCode != tt.wantStatus || string(record.ResponseBody) != tt.wantBody {
+					t.Errorf("Do() = %d %s replayed=%t, want %d %s replayed=%t",
+						record.StatusCode, record.ResponseBody, replayed, tt.wantStatus, tt.wantBody, tt.wantReplayed)
+				}
+			}
+
+			stored, ok := repo.records[idempotencyID{scope, key}]
+			switch {
+			case tt.wantStored == nil && ok:
+				t.Errorf("key is still stored: %+v", stored)
+			case tt.wantStored != nil && !ok:
+				t.Errorf("key is not stored")
+			case tt.wantStored != nil && (stored.Fingerprint != tt.wantStored.Fingerprint ||
+				stored.StatusCode != tt.wantStored.StatusCode ||
+				string(stored.ResponseBody) != string(tt.wantStored.ResponseBody)):
+				t.Errorf("stored key = %+v, want %+v", stored, *tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestIdempotencyDoRetryAfterFailure(t *testing.T) {
+	repo := &fakeIdempotencyKeys{records: map[idempotencyID]model.IdempotencyKey{}}
+	svc := NewIdempotencyService(repo, repo, time.Hour)
+	ctx := context.Background()
+
+	calls := 0
+	fn := func(context.Context) (int, []byte, error) {
+		calls++
+		if calls == 1 {
+			return 0, nil, errors.New("temporary failure")
+		}
+		return http.StatusCreated, []byte(`{"id":1}`), nil
+	}
+
+	if _, _, err := svc.Do(ctx, "s", "k", "a", fn); err == nil {
+		t.Fatalf("first Do() succeeded, want the error of fn")
+	}
+	for i, wantReplayed := range []bool{false, true} {
+		record, replayed, err := svc.Do(ctx, "s", "k", "a", fn)
+		if err != nil {
+			t.Fatalf("Do() #%d error = %v", i+2, err)
+		}
+		if replayed != wantReplayed || record.StatusCode != http.StatusCreated {
+			t.Errorf("Do() #%d = %d replayed=%t, want %d replayed=%t", i+2, record.StatusCode, replayed, http.StatusCreated, wantReplayed)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times, want 2", calls)
+	}
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	base, err := RequestFingerprint(map[string]any{"service_name": "Netflix", "price": 400})
+	if err != nil {
+		t.Fatalf("RequestFingerprint() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  any
+		same bool
+	}{
+		{name: "same request", req: map[string]any{"service_name": "Netflix", "price": 400}, same: true},
+		{name: "struct with the same fields", req: struct {
+			Price       int    `json:"price"`
+			ServiceName string `json:"service_name"`
+		}{400, "Netflix"}, same: true},
+		{name: "other value", req: map[string]any{"service_name": "Netflix", "price": 500}},
+		{name: "extra field", req: map[string]any{"service_name": "Netflix", "price": 400, "currency": "RUB"}},
+	}
+	for _, tt := range tests {
+		got, err := RequestFingerprint(tt.req)
+		if err != nil {
+			t.Fatalf("%s: RequestFingerprint() error = %v", tt.name, err)
+		}
+		if (got == base) != tt.same {
+			t.Errorf("%s: fingerprint equal = %t, want %t", tt.name, got == base, tt.same)
+		}
+	}
+}
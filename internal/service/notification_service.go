@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/billing"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/notify"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+const notificationBatchSize = 100
+
+type NotificationService interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req *model.UpdateNotificationPreferencesRequest) (*model.NotificationPreference, error)
+	// SendReminders emails users about billing and end dates of their
+	// subscriptions that fall within their reminder window after now, and
+	// returns how many reminders were sent. Each reminder is sent once.
+	SendReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type notificationService struct {
+	readRepo   read_repository.NotificationReadRepository
+	writeRepo  write_repository.NotificationWriteRepository
+	subSvc     SubscriptionQueryService
+	mailer     notify.Mailer
+	templates  *notify.Templates
+	daysBefore int
+}
+
+func NewNotificationService(
+	readRepo read_repository.NotificationReadRepository,
+	writeRepo write_repository.NotificationWriteRepository,
+	subSvc SubscriptionQueryService,
+	mailer notify.Mailer,
+	templates *notify.Templates,
+	daysBefore int,
+) NotificationService {
+	return &notificationService{
+		readRepo:   readRepo,
+		writeRepo:  writeRepo,
+		subSvc:     subSvc,
+		mailer:     mailer,
+		templates:  templates,
+		daysBefore: daysBefore,
+	}
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreference, error) {
+	return s.readRepo.GetPreferences(ctx, userID)
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req *model.UpdateNotificationPreferencesRequest) (*model.NotificationPreference, error) {
+	log := logger.Get()
+	log.Infof("[NotificationService] UpdatePreferences called | userID=%s", userID)
+
+	pref := &model.NotificationPreference{
+		UserID:              userID,
+		Email:               req.Email,
+		RenewalReminders:    defaultBool(req.RenewalReminders, true),
+		ExpirationReminders: defaultBool(req.ExpirationReminders, true),
+		DaysBefore:          req.DaysBefore,
+	}
+	if err := s.writeRepo.SavePreferences(ctx, pref); err != nil {
+		log.Errorf("[NotificationService] UpdatePreferences error | userID=%s err=%v", userID, err)
+		return nil, err
+	}
+
+	log.Infof("[NotificationService] UpdatePreferences success | userID=%s", userID)
+	return pref, nil
+}
+
+func (s *notificationService) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	log := logger.Get()
+	log.Infof("[NotificationService] SendReminders called | now=%s", now.Format(time.RFC3339))
+
+	var sent int
+	var after uuid.UUID
+	for {
+		prefs, err := s.readRepo.ListPreferences(ctx, after, notificationBatchSize)
+		if err != nil {
+			log.Errorf("[NotificationService] SendReminders list error | err=%v", err)
+			return sent, err
+		}
+
+		for i := range prefs {
+			n, err := s.remindUser(ctx, &prefs[i], now)
+			sent += n
+			if err != nil {
+				log.Errorf("[NotificationService] SendReminders error | userID=%s err=%v", prefs[i].UserID, err)
+				return sent, err
+			}
+		}
+
+		if len(prefs) < notificationBatchSize {
+			break
+		}
+		after = prefs[len(prefs)-1].UserID
+	}
+
+	log.Infof("[NotificationService] SendReminders success | sent=%d", sent)
+	return sent, nil
+}
+
+// remindUser sends the reminders due for one user. A reminder that cannot be
+// delivered is logged and retried on the next run; only storage errors abort
+// the run.
+func (s *notificationService) remindUser(ctx context.Context, pref *model.NotificationPreference, now time.Time) (int, error) {
+	log := logger.Get()
+
+	days := s.daysBefore
+	if pref.DaysBefore != nil {
+		days = *pref.DaysBefore
+	}
+	horizon := now.AddDate(0, 0, days)
+
+	subs, err := s.subSvc.GetByUserID(ctx, pref.UserID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	for i := range subs {
+		sub := &subs[i]
+		for _, r := range dueReminders(pref, sub, now, horizon) {
+			ok, err := s.remind(ctx, pref, sub, r.kind, r.date, now)
+			if err != nil {
+				return sent, err
+			}
+			if ok {
+				sent++
+			} else {
+				log.Infof("[NotificationService] Reminder skipped | subscriptionID=%d kind=%s", sub.ID, r.kind)
+			}
+		}
+	}
+	return sent, nil
+}
+
+type dueReminder struct {
+	kind string
+	date time.Time
+}
+
+// dueReminders lists the reminders about sub whose date is in (now, horizon].
+// The first billing date is not a renewal and gets no reminder, unless it
+// ends a trial.
+func dueReminders(pref *model.NotificationPreference, sub *model.SubscriptionResponse, now, horizon time.Time) []dueReminder {
+	var due []dueReminder
+	if pref.RenewalReminders {
+		next, ok := billing.NextChargeDate(fromSubscriptionResponse(sub), now)
+		if ok && !next.Equal(sub.StartDate) && !next.After(horizon) {
+			due = append(due, dueReminder{kind: model.ReminderRenewal, date: next})
+		}
+	}
+	if pref.ExpirationReminders && sub.EndDate != nil && sub.EndDate.After(now) && !sub.EndDate.After(horizon) {
+		due = append(due, dueReminder{kind: model.ReminderExpiration, date: *sub.EndDate})
+	}
+	return due
+}
+
+// remind claims a reminder in the dedupe table and sends it. It returns false
+// if the reminder was already sent or could not be delivered; in the latter
+// case the claim is released so that the next run retries.
+func (s *notificationService) remind(ctx context.Context, pref *model.NotificationPreference, sub *model.SubscriptionResponse, kind string, date, now time.Time) (bool, error) {
+	log := logger.Get()
+
+	reminder := &model.SentReminder{
+		SubscriptionID: sub.ID,
+		Kind:           kind,
+		DueDate:        date,
+		UserID:         pref.UserID,
+		Email:          pref.Email,
+		SentAt:         now,
+	}
+	claimed, err := s.writeRepo.ClaimReminder(ctx, reminder)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	msg, err := s.templates.Render(kind, notify.ReminderData{
+		Email:        pref.Email,
+		Subscription: *sub,
+		DueDate:      date,
+		DaysLeft:     int(date.Sub(now.UTC().Truncate(24*time.Hour)).Hours() / 24),
+	})
+	if err == nil {
+		err = s.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		log.Warnf("[NotificationService] Reminder not sent | subscriptionID=%d kind=%s err=%v", sub.ID, kind, err)
+		return false, s.writeRepo.ReleaseReminder(ctx, reminder.ID)
+	}
+
+	log.Infof("[NotificationService] Reminder sent | subscriptionID=%d kind=%s due=%s", sub.ID, kind, date.Format("2006-01-02"))
+	return true, nil
+}
+
+func defaultBool(val *bool, def bool) bool {
+	if val != nil {
+		return *val
+	}
+	return def
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/events"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+const outboxBatchSize = 100
+
+type OutboxRelayService interface {
+	// RelayPending publishes pending outbox messages in the order they were
+	// written and returns how many were published. A message that fails to
+	// publish holds back the later messages with the same ordering key until
+	// a later run publishes it, or until it has failed maxAttempts times and
+	// is parked as failed.
+	RelayPending(ctx context.Context, now time.Time) (int, error)
+	// PurgePublished deletes messages published before the given time.
+	PurgePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRelayService struct {
+	repo        write_repository.OutboxWriteRepository
+	tx          write_repository.Transactor
+	publisher   events.Publisher
+	maxAttempts int
+}
+
+func NewOutboxRelayService(
+	repo write_repository.OutboxWriteRepository,
+	tx write_repository.Transactor,
+	publisher events.Publisher,
+	maxAttempts int,
+) OutboxRelayService {
+	return &outboxRelayService{repo: repo, tx: tx, publisher: publisher, maxAttempts: maxAttempts}
+}
+
+func (s *outboxRelayService) RelayPending(ctx context.Context, now time.Time) (int, error) {
+	log := logger.Get()
+	log.Infof("[OutboxRelay] RelayPending called | now=%s", now.Format(time.RFC3339))
+
+	var total int
+	var held []string
+	for ctx.Err() == nil {
+		var published, listed int
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			locked, err := s.repo.TryLock(ctx)
+			if err != nil || !locked {
+				return err
+			}
+
+			msgs, err := s.repo.ListPending(ctx, held, outboxBatchSize)
+			if err != nil {
+				return err
+			}
+			listed = len(msgs)
+
+			failed := make(map[string]bool)
+			ids := make([]uint, 0, len(msgs))
+			for i := range msgs {
+				msg := &msgs[i]
+				if failed[msg.OrderingKey] {
+					continue
+				}
+				// a savepoint per message undoes the writes of subscribers
+				// that ran before the one that failed
+				err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+					return s.publisher.Publish(ctx, msg)
+				})
+				if err != nil {
+					if msg.Attempts+1 >= s.maxAttempts {
+						// parked messages no longer hold back their key
+						log.Errorf("[OutboxRelay] Publish failed, message parked | id=%d eventType=%s key=%s attempts=%d err=%v",
+							msg.ID, msg.EventType, msg.OrderingKey, msg.Attempts+1, err)
+						if err := s.repo.MarkFailed(ctx, msg.ID, err.Error(), &now); err != nil {
+							return err
+						}
+						continue
+					}
+					log.Warnf("[OutboxRelay] Publish failed | id=%d eventType=%s key=%s err=%v", msg.ID, msg.EventType, msg.OrderingKey, err)
+					failed[msg.OrderingKey] = true
+					held = append(held, msg.OrderingKey)
+					if err := s.repo.MarkFailed(ctx, msg.ID, err.Error(), nil); err != nil {
+						return err
+					}
+					continue
+				}
+				ids = append(ids, msg.ID)
+			}
+			published = len(ids)
+			return s.repo.MarkPublished(ctx, ids, now)
+		})
+		if err != nil {
+			log.Errorf("[OutboxRelay] RelayPending error | err=%v", err)
+			return total, err
+		}
+
+		total += published
+		if listed < outboxBatchSize {
+			break
+		}
+	}
+
+	log.Infof("[OutboxRelay] RelayPending success | published=%d held=%d", total, len(held))
+	return total, nil
+}
+
+func (s *outboxRelayService) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.DeletePublished(ctx, before)
+}
+
+// recordSubscriptionEvent writes an event about sub to the outbox. It must be
+// called within the same transaction as the change.
+func recordSubscriptionEvent(ctx context.Context, repo write_repository.OutboxWriteRepository, eventType string, sub *model.SubscriptionResponse) error {
+	event := model.Event{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      sub,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return repo.Create(ctx, &model.OutboxMessage{
+		EventID:     event.ID,
+		EventType:   eventType,
+		OrderingKey: fmt.Sprintf("%s:%d", model.AuditEntitySubscription, sub.ID),
+		Payload:     payload,
+		CreatedAt:   event.CreatedAt,
+	})
+}
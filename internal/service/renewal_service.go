@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/billing"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+const renewalBatchSize = 100
+
+type RenewalService interface {
+	RenewDue(ctx context.Context, now time.Time) (int64, error)
+}
+
+type renewalService struct {
+	subRepo    read_repository.SubscriptionReadRepository
+	chargeRepo write_repository.ChargeWriteRepository
+	tx         write_repository.Transactor
+}
+
+func NewRenewalService(
+	subRepo read_repository.SubscriptionReadRepository,
+	chargeRepo write_repository.ChargeWriteRepository,
+	tx write_repository.Transactor,
+) RenewalService {
+	return &renewalService{subRepo: subRepo, chargeRepo: chargeRepo, tx: tx}
+}
+
+// RenewDue writes a charge for every billing date up to now that has not been
+// materialized yet. Subscriptions locked by another instance are skipped and
+// picked up on its next run.
+func (s *renewalService) RenewDue(ctx context.Context, now time.Time) (int64, error) {
+	log := logger.Get()
+	log.Infof("[RenewalService] RenewDue called | now=%s", now.Format(time.RFC3339))
+
+	query := model.SubscriptionListQuery{
+		Filter:      model.SubscriptionFilter{StartDateTo: &now},
+		Sort:        "id",
+		Order:       model.SortAsc,
+		Limit:       renewalBatchSize,
+		WithHistory: true,
+	}
+
+	var created int64
+	for {
+		page, err := s.subRepo.GetAll(ctx, query)
+		if err != nil {
+			log.Errorf("[RenewalService] RenewDue list error | err=%v", err)
+			return created, err
+		}
+
+		for i := range page.Items {
+			n, err := s.renew(ctx, &page.Items[i], now)
+			if err != nil {
+				log.Errorf("[RenewalService] RenewDue error | subscriptionID=%d err=%v", page.Items[i].ID, err)
+				return created, err
+			}
+			created += n
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	log.Infof("[RenewalService] RenewDue success | created=%d", created)
+	return created, nil
+}
+
+func (s *renewalService) renew(ctx context.Context, sub *model.Subscription, now time.Time) (int64, error) {
+	var created int64
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		locked, err := s.chargeRepo.TryLockSubscription(ctx, sub.ID)
+		if err != nil || !locked {
+			return err
+		}
+
+		last, err := s.chargeRepo.LastPeriodStart(ctx, sub.ID)
+		if err != nil {
+			return err
+		}
+
+		var from time.Time
+		if last != nil {
+			from = *last
+		}
+		var charges []model.Charge
+		for _, p := range billing.Periods(sub, from, now) {
+			if last != nil && !p.Start.After(*last) {
+				continue
+			}
+			price := p.Price(sub)
+			if (p.Trial && price.Amount == 0) || sub.PausedOn(p.Start) {
+				// free trials and periods starting during a pause are not billed
+				continue
+			}
+			charges = append(charges, model.Charge{
+				SubscriptionID: sub.ID,
+				UserID:         sub.UserID,
+				ServiceName:    sub.ServiceName,
+				PeriodStart:    p.Start,
+				PeriodEnd:      p.End,
+				Amount:         price.Amount,
+				Currency:       price.Currency,
+			})
+		}
+
+		created, err = s.chargeRepo.CreateMissing(ctx, charges)
+		return err
+	})
+	return created, err
+}
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/domainerr"
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+// RequestValidator applies the validation rules of the API to requests that
+// do not come through the HTTP binding, so that imported rows are checked and
+// reported exactly like POST /subscriptions.
+type RequestValidator interface {
+	// ValidateStruct checks the binding rules of req.
+	ValidateStruct(req any) error
+	// TranslateError turns a decoding error into a domain error.
+	TranslateError(err error) error
+}
+
+type SubscriptionImportService interface {
+	Import(ctx context.Context, r io.Reader, opts model.ImportOptions) (*model.SubscriptionImportResponse, error)
+}
+
+type subscriptionImportService struct {
+	commandSvc SubscriptionCommandService
+	readRepo   read_repository.SubscriptionReadRepository
+	catalog    CatalogService
+	tx         write_repository.Transactor
+	validator  RequestValidator
+}
+
+func NewSubscriptionImportService(
+	commandSvc SubscriptionCommandService,
+	readRepo read_repository.SubscriptionReadRepository,
+	catalog CatalogService,
+	tx write_repository.Transactor,
+	validator RequestValidator,
+) SubscriptionImportService {
+	return &subscriptionImportService{
+		commandSvc: commandSvc,
+		readRepo:   readRepo,
+		catalog:    catalog,
+		tx:         tx,
+		validator:  validator,
+	}
+}
+
+var csvImportColumns = map[string]bool{
+	"service_name":     true,
+	"price":            true,
+	"currency":         false,
+	"user_id":          true,
+	"start_date":       true,
+	"end_date":         false,
+	"billing_period":   false,
+	"billing_interval": false,
+	"trial_end_date":   false,
+	"trial_price":      false,
+	"service_id":       false,
+}
+
+// importRow is a parsed line of the import file. err is set when the line
+// could not be decoded into a request.
+type importRow struct {
+	line int
+	req  model.CreateSubscriptionRequest
+	err  error
+}
+
+// importKey identifies the subscription a row creates. serviceID is set when
+// the service of the row resolves to a catalog entry, serviceName otherwise.
+type importKey struct {
+	userID      uuid.UUID
+	serviceID   uint
+	serviceName string
+	startDate   time.Time
+}
+
+// Import creates subscriptions from a CSV or NDJSON file. Every row is
+// validated like a CreateSubscriptionRequest; rows duplicating an existing
+// subscription or an earlier row (same user, service and start date, with
+// catalog services compared by entry) are skipped. In atomic mode nothing is
+// created unless every row succeeds.
+func (s *subscriptionImportService) Import(ctx context.Context, r io.Reader, opts model.ImportOptions) (*model.SubscriptionImportResponse, error) {
+	log := logger.Get()
+	log.Infof("[ImportService] Import called | format=%s mode=%s dryRun=%t", opts.Format, opts.Mode, opts.DryRun)
+
+	if opts.Mode == "" {
+		opts.Mode = model.ImportModeAtomic
+	}
+
+	var rows []importRow
+	var err error
+	switch opts.Format {
+	case model.ImportFormatCSV:
+		rows, err = parseCSVImport(r)
+	case model.ImportFormatNDJSON:
+		rows, err = parseNDJSONImport(r)
+	default:
+		err = model.ErrUnsupportedImportFormat
+	}
+	if err != nil {
+		log.Warnf("[ImportService] Import parse error | err=%v", err)
+		return nil, err
+	}
+
+	res := &model.SubscriptionImportResponse{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+		Total:  len(rows),
+		Rows:   make([]model.ImportRowResult, len(rows)),
+	}
+
+	services, err := s.catalog.List(ctx)
+	if err != nil {
+		log.Errorf("[ImportService] Import catalog error | err=%v", err)
+		return nil, err
+	}
+	matcher := model.NewServiceMatcher(services)
+
+	// pending holds the indexes of the rows that passed validation.
+	var pending []int
+	seen := map[importKey]int{}
+	for i := range rows {
+		row := &rows[i]
+		res.Rows[i] = model.ImportRowResult{Line: row.line}
+
+		if err := s.checkRow(row); err != nil {
+			failRow(&res.Rows[i], err)
+			continue
+		}
+
+		key, filter := importIdentity(&row.req, services, matcher)
+		if line, ok := seen[key]; ok {
+			skipRow(&res.Rows[i], "duplicate_row", fmt.Sprintf("duplicates line %d", line))
+			continue
+		}
+		seen[key] = row.line
+
+		exists, err := s.readRepo.Exists(ctx, filter, row.req.StartDate)
+		if err != nil {
+			log.Errorf("[ImportService] Import duplicate check error | line=%d err=%v", row.line, err)
+			return nil, err
+		}
+		if exists {
+			skipRow(&res.Rows[i], "already_exists", "subscription already exists")
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	switch {
+	case opts.DryRun:
+		for _, i := range pending {
+			res.Rows[i].Status = model.ImportRowValid
+		}
+	case opts.Mode == model.ImportModeAtomic:
+		if err := s.createAtomic(ctx, rows, pending, res); err != nil {
+			log.Errorf("[ImportService] Import error | err=%v", err)
+			return nil, err
+		}
+	default:
+		for _, i := range pending {
+			sub, err := s.commandSvc.Create(ctx, &rows[i].req)
+			if err != nil {
+				log.Warnf("[ImportService] Import row failed | line=%d err=%v", rows[i].line, err)
+				failRow(&res.Rows[i], err)
+				continue
+			}
+			createRow(&res.Rows[i], sub.ID)
+		}
+	}
+
+	for _, row := range res.Rows {
+		switch row.Status {
+		case model.ImportRowCreated:
+			res.Created++
+		case model.ImportRowValid:
+			res.Valid++
+		case model.ImportRowSkipped:
+			res.Skipped++
+		case model.ImportRowFailed:
+			res.Failed++
+		}
+	}
+
+	log.Infof("[ImportService] Import success | total=%d created=%d valid=%d skipped=%d failed=%d",
+		res.Total, res.Created, res.Valid, res.Skipped, res.Failed)
+	return res, nil
+}
+
+// importIdentity returns the dedupe key of req and the filter that finds an
+// existing subscription with the same user and service. A service given by id
+// or by a name or alias of a catalog entry is compared by the entry, because
+// the subscription is stored under the canonical name.
+func importIdentity(req *model.CreateSubscriptionRequest, services []model.Service, matcher *model.ServiceMatcher) (importKey, model.SubscriptionFilter) {
+	userID := req.UserID
+	key := importKey{userID: userID, startDate: req.StartDate.UTC()}
+	filter := model.SubscriptionFilter{UserID: &userID}
+
+	var svc *model.Service
+	if req.ServiceID != nil {
+		for i := range services {
+			if services[i].ID == *req.ServiceID {
+				svc = &services[i]
+			}
+		}
+		if svc == nil {
+			// unknown ids fail on create; keep them apart from names
+			key.serviceID = *req.ServiceID
+			filter.ServiceID = req.ServiceID
+			return key, filter
+		}
+	} else {
+		svc = matcher.Match(req.ServiceName)
+	}
+
+	if svc == nil {
+		name := req.ServiceName
+		key.serviceName = name
+		filter.ServiceName = &name
+		return key, filter
+	}
+	key.serviceID = svc.ID
+	filterByService(&filter, svc)
+	return key, filter
+}
+
+// createAtomic creates the pending rows in one transaction. If any row has
+// failed, during validation or on insert, nothing is kept and the remaining
+// rows are reported as skipped.
+func (s *subscriptionImportService) createAtomic(ctx context.Context, rows []importRow, pending []int, res *model.SubscriptionImportResponse) error {
+	abort := func() {
+		for _, i := range pending {
+			if res.Rows[i].Status != model.ImportRowFailed {
+				res.Rows[i].SubscriptionID = nil
+				skipRow(&res.Rows[i], "import_aborted", "not imported because other rows failed")
+			}
+		}
+	}
+
+	for _, row := range res.Rows {
+		if row.Status == model.ImportRowFailed {
+			abort()
+			return nil
+		}
+	}
+
+	var rowErr error
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, i := range pending {
+			sub, err := s.commandSvc.Create(ctx, &rows[i].req)
+			if err != nil {
+				failRow(&res.Rows[i], err)
+				rowErr = err
+				return err
+			}
+			createRow(&res.Rows[i], sub.ID)
+		}
+		return nil
+	})
+	if err != nil && rowErr != nil {
+		abort()
+		return nil
+	}
+	return err
+}
+
+// checkRow applies the same checks as SubscriptionCommandService.Create.
+func (s *subscriptionImportService) checkRow(row *importRow) error {
+	if row.err != nil {
+		return s.validator.TranslateError(row.err)
+	}
+	if err := s.validator.ValidateStruct(&row.req); err != nil {
+		return err
+	}
+	if _, _, err := normalizeBilling(row.req.BillingPeriod, row.req.BillingInterval); err != nil {
+		return err
+	}
+	if row.req.EndDate != nil && row.req.EndDate.Before(row.req.StartDate) {
+		return model.ErrInvalidDateRange
+	}
+	return validateTrial(row.req.StartDate, row.req.TrialEndDate, row.req.TrialPrice)
+}
+
+func createRow(row *model.ImportRowResult, id uint) {
+	row.Status = model.ImportRowCreated
+	row.SubscriptionID = &id
+}
+
+func skipRow(row *model.ImportRowResult, code, reason string) {
+	row.Status = model.ImportRowSkipped
+	row.Code = code
+	row.Reason = reason
+}
+
+func failRow(row *model.ImportRowResult, err error) {
+	row.Status = model.ImportRowFailed
+	row.SubscriptionID = nil
+	if de, ok := domainerr.As(err); ok && de.Kind != domainerr.KindInternal {
+		row.Code = de.Code
+		row.Reason = err.Error()
+		row.Errors = de.Fields
+		return
+	}
+	row.Code = domainerr.CodeInternal
+	row.Reason = "internal error"
+}
+
+// parseCSVImport reads a CSV file with a header row naming the columns, in
+// any order. Empty cells are treated as missing values.
+func parseCSVImport(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: file is empty", model.ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidImportFile, err)
+	}
+
+	columns := make([]string, len(header))
+	present := map[string]bool{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := csvImportColumns[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", model.ErrInvalidImportFile, name)
+		}
+		if present[name] {
+			return nil, fmt.Errorf("%w: duplicate column %q", model.ErrInvalidImportFile, name)
+		}
+		columns[i] = name
+		present[name] = true
+	}
+	for name, required := range csvImportColumns {
+		if required && !present[name] {
+			return nil, fmt.Errorf("%w: missing column %q", model.ErrInvalidImportFile, name)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %w", model.ErrInvalidImportFile, err)
+		}
+		if len(rows) == model.MaxImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows are allowed", model.ErrTooManyImportRows, model.MaxImportRows)
+		}
+
+		row := importRow{line: line}
+		if err != nil {
+			row.err = domainerr.Wrap(err, domainerr.KindValidation, "invalid_row",
+				fmt.Sprintf("expected %d fields, got %d", len(columns), len(record)))
+		} else {
+			row.req, row.err = csvImportRequest(columns, record)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func csvImportRequest(columns, record []string) (model.CreateSubscriptionRequest, error) {
+	var req model.CreateSubscriptionRequest
+	var fields []domainerr.FieldError
+	invalid := func(field, rule, message string) {
+		fields = append(fields, domainerr.FieldError{Field: field, Rule: rule, Message: message})
+	}
+
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		switch columns[i] {
+		case "service_name":
+			req.ServiceName = value
+		case "price":
+			amount, err := model.ParseAmount(value)
+			if err != nil {
+				invalid("price", "type", "must be a decimal amount")
+			}
+			req.Price = amount
+		case "currency":
+			req.Currency = strings.ToUpper(value)
+		case "user_id":
+			id, err := uuid.Parse(value)
+			if err != nil {
+				invalid("user_id", "uuid", "must be a valid UUID")
+			}
+			req.UserID = id
+		case "start_date":
+			date, err := parseImportDate(value)
+			if err != nil {
+				invalid("start_date", "datetime", "must be an RFC3339 timestamp or YYYY-MM-DD")
+			}
+			req.StartDate = date
+		case "end_date":
+			date, err := parseImportDate(value)
+			if err != nil {
+				invalid("end_date", "datetime", "must be an RFC3339 timestamp or YYYY-MM-DD")
+			}
+			req.EndDate = &date
+		case "billing_period":
+			req.BillingPeriod = strings.ToLower(value)
+		case "billing_interval":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				invalid("billing_interval", "type", "must be an integer")
+			}
+			req.BillingInterval = n
+		case "trial_end_date":
+			date, err := parseImportDate(value)
+			if err != nil {
+				invalid("trial_end_date", "datetime", "must be an RFC3339 timestamp or YYYY-MM-DD")
+			}
+			req.TrialEndDate = &date
+		case "trial_price":
+			amount, err := model.ParseAmount(value)
+			if err != nil {
+				invalid("trial_price", "type", "must be a decimal amount")
+			}
+			req.TrialPrice = amount
+		case "service_id":
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				invalid("service_id", "type", "must be an integer")
+			}
+			serviceID := uint(id)
+			req.ServiceID = &serviceID
+		}
+	}
+
+	if len(fields) > 0 {
+		return req, domainerr.InvalidFields(nil, fields)
+	}
+	return req, nil
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// parseNDJSONImport reads one JSON object per line; blank lines are ignored.
+func parseNDJSONImport(r io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []importRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if len(rows) == model.MaxImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows are allowed", model.ErrTooManyImportRows, model.MaxImportRows)
+		}
+
+		row := importRow{line: line}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&row.req); err != nil {
+			row.err = err
+		} else if dec.More() {
+			row.err = domainerr.Validation("invalid_row", "line must contain a single JSON object")
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidImportFile, err)
+	}
+	return rows, nil
+}
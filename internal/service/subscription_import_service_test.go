@@ -0,0 +1,100 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+func TestImportIdentity(t *testing.T) {
+	services := []model.Service{
+		{ID: 1, Name: "Yandex Plus", Aliases: []string{"Яндекс Плюс"}},
+		{ID: 2, Name: "Netflix"},
+	}
+	matcher := model.NewServiceMatcher(services)
+
+	user, otherUser := uuid.New(), uuid.New()
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	id := func(v uint) *uint { return &v }
+	req := func(name string, serviceID *uint) model.CreateSubscriptionRequest {
+		return model.CreateSubscriptionRequest{ServiceName: name, ServiceID: serviceID, UserID: user, StartDate: start}
+	}
+
+	otherStart := req("Netflix", nil)
+	otherStart.StartDate = start.AddDate(0, 1, 0)
+	otherOwner := req("Netflix", nil)
+	otherOwner.UserID = otherUser
+	sameInstant := req("Netflix", nil)
+	sameInstant.StartDate = start.In(time.FixedZone("MSK", 3*60*60))
+
+	tests := []struct {
+		name string
+		a, b model.CreateSubscriptionRequest
+		same bool
+	}{
+		{name: "same name", a: req("Netflix", nil), b: req("Netflix", nil), same: true},
+		{name: "name spelling", a: req("Yandex Plus", nil), b: req(" yandex  PLUS", nil), same: true},
+		{name: "alias and name", a: req("Яндекс Плюс", nil), b: req("Yandex Plus", nil), same: true},
+		{name: "id and name", a: req("", id(1)), b: req("Yandex Plus", nil), same: true},
+		{name: "id and alias", a: req("", id(1)), b: req("Яндекс Плюс", nil), same: true},
+		{name: "same start in another zone", a: req("Netflix", nil), b: sameInstant, same: true},
+		{name: "different entries", a: req("Netflix", nil), b: req("Yandex Plus", nil)},
+		{name: "unknown id and name", a: req("", id(99)), b: req("99", nil)},
+		{name: "different start", a: req("Netflix", nil), b: otherStart},
+		{name: "different user", a: req("Netflix", nil), b: otherOwner},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyA, _ := importIdentity(&tt.a, services, matcher)
+			keyB, _ := importIdentity(&tt.b, services, matcher)
+			if (keyA == keyB) != tt.same {
+				t.Errorf("keys %+v and %+v: equal = %t, want %t", keyA, keyB, keyA == keyB, tt.same)
+			}
+		})
+	}
+}
+
+func TestImportIdentityFilter(t *testing.T) {
+	services := []model.Service{{ID: 1, Name: "Yandex Plus", Aliases: []string{"Яндекс Плюс"}}}
+	matcher := model.NewServiceMatcher(services)
+	user := uuid.New()
+	id := func(v uint) *uint { return &v }
+	name := func(v string) *string { return &v }
+
+	tests := []struct {
+		name string
+		req  model.CreateSubscriptionRequest
+		want model.SubscriptionFilter
+	}{
+		{
+			name: "catalog entry covers unlinked names",
+			req:  model.CreateSubscriptionRequest{ServiceName: "яндекс плюс", UserID: user},
+			want: model.SubscriptionFilter{UserID: &user, ServiceID: id(1), ServiceNames: []string{"Yandex Plus", "Яндекс Плюс"}},
+		},
+		{
+			name: "catalog id",
+			req:  model.CreateSubscriptionRequest{ServiceID: id(1), UserID: user},
+			want: model.SubscriptionFilter{UserID: &user, ServiceID: id(1), ServiceNames: []string{"Yandex Plus", "Яндекс Плюс"}},
+		},
+		{
+			name: "unknown id",
+			req:  model.CreateSubscriptionRequest{ServiceID: id(99), UserID: user},
+			want: model.SubscriptionFilter{UserID: &user, ServiceID: id(99)},
+		},
+		{
+			name: "name outside the catalog",
+			req:  model.CreateSubscriptionRequest{ServiceName: "Kinopoisk", UserID: user},
+			want: model.SubscriptionFilter{UserID: &user, ServiceName: name("Kinopoisk")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := importIdentity(&tt.req, services, matcher); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importIdentity() filter = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
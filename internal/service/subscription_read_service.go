@@ -2,26 +2,48 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/winnamu6/go-subscription-service/internal/billing"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
 	"github.com/winnamu6/go-subscription-service/internal/model"
 	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"gorm.io/gorm"
 )
 
 type SubscriptionQueryService interface {
 	GetByID(ctx context.Context, id uint) (*model.SubscriptionResponse, error)
+	GetDeletedByID(ctx context.Context, id uint) (*model.SubscriptionResponse, error)
 	GetByUserID(ctx context.Context, userID string) ([]model.SubscriptionResponse, error)
-	GetAll(ctx context.Context) ([]model.SubscriptionResponse, error)
-	SumPriceByFilter(ctx context.Context, userID *string, serviceName *string, startDate, endDate time.Time) (int, error)
+	GetAll(ctx context.Context, query model.SubscriptionListQuery) (*model.SubscriptionListResponse, error)
+	// Export calls fn for every subscription matching query without loading
+	// them all into memory.
+	Export(ctx context.Context, query model.SubscriptionListQuery, fn func(sub *model.SubscriptionResponse) error) error
+	SumPriceByFilter(ctx context.Context, query model.CostQuery) (*model.Money, error)
+	Breakdown(ctx context.Context, query model.CostQuery) (*model.BreakdownResponse, error)
+	GetPriceHistory(ctx context.Context, id uint) ([]model.SubscriptionPrice, error)
+	GetPauseHistory(ctx context.Context, id uint) ([]model.SubscriptionPause, error)
 }
 
 type subscriptionQueryService struct {
-	readRepo read_repository.SubscriptionReadRepository
+	readRepo  read_repository.SubscriptionReadRepository
+	priceRepo read_repository.SubscriptionPriceReadRepository
+	pauseRepo read_repository.SubscriptionPauseReadRepository
+	rateSvc   ExchangeRateQueryService
+	catalog   CatalogService
 }
 
-func NewSubscriptionQueryService(readRepo read_repository.SubscriptionReadRepository) SubscriptionQueryService {
-	return &subscriptionQueryService{readRepo: readRepo}
+func NewSubscriptionQueryService(
+	readRepo read_repository.SubscriptionReadRepository,
+	priceRepo read_repository.SubscriptionPriceReadRepository,
+	pauseRepo read_repository.SubscriptionPauseReadRepository,
+	rateSvc ExchangeRateQueryService,
+	catalog CatalogService,
+) SubscriptionQueryService {
+	return &subscriptionQueryService{readRepo: readRepo, priceRepo: priceRepo, pauseRepo: pauseRepo, rateSvc: rateSvc, catalog: catalog}
 }
 
 func (s *subscriptionQueryService) GetByID(ctx context.Context, id uint) (*model.SubscriptionResponse, error) {
@@ -38,6 +60,20 @@ func (s *subscriptionQueryService) GetByID(ctx context.Context, id uint) (*model
 	return toSubscriptionResponse(sub), nil
 }
 
+func (s *subscriptionQueryService) GetDeletedByID(ctx context.Context, id uint) (*model.SubscriptionResponse, error) {
+	log := logger.Get()
+	log.Infof("[QueryService] GetDeletedByID called | id=%d", id)
+
+	sub, err := s.readRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		log.Errorf("[QueryService] GetDeletedByID error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[QueryService] GetDeletedByID success | id=%d", id)
+	return toSubscriptionResponse(sub), nil
+}
+
 func (s *subscriptionQueryService) GetByUserID(ctx context.Context, userID string) ([]model.SubscriptionResponse, error) {
 	log := logger.Get()
 	log.Infof("[QueryService] GetByUserID called | userID=%s", userID)
@@ -52,49 +88,329 @@ func (s *subscriptionQueryService) GetByUserID(ctx context.Context, userID strin
 	return toSubscriptionResponseList(subs), nil
 }
 
-func (s *subscriptionQueryService) GetAll(ctx context.Context) ([]model.SubscriptionResponse, error) {
+func (s *subscriptionQueryService) GetAll(ctx context.Context, query model.SubscriptionListQuery) (*model.SubscriptionListResponse, error) {
 	log := logger.Get()
-	log.Info("[QueryService] GetAll called")
+	log.Infof("[QueryService] GetAll called | sort=%s order=%s limit=%d offset=%d", query.Sort, query.Order, query.Limit, query.Offset)
+
+	if query.Limit <= 0 {
+		query.Limit = model.DefaultPageLimit
+	}
+	if query.Limit > model.MaxPageLimit {
+		query.Limit = model.MaxPageLimit
+	}
+	if query.Cursor != "" {
+		query.Offset = 0
+	}
+	if err := s.resolveServiceFilter(ctx, &query.Filter); err != nil {
+		log.Errorf("[QueryService] GetAll error | err=%v", err)
+		return nil, err
+	}
 
-	subs, err := s.readRepo.GetAll(ctx)
+	page, err := s.readRepo.GetAll(ctx, query)
 	if err != nil {
 		log.Errorf("[QueryService] GetAll error | err=%v", err)
 		return nil, err
 	}
 
-	log.Infof("[QueryService] GetAll success | count=%d", len(subs))
-	return toSubscriptionResponseList(subs), nil
+	log.Infof("[QueryService] GetAll success | count=%d total=%d", len(page.Items), page.Total)
+	return &model.SubscriptionListResponse{
+		Items:      toSubscriptionResponseList(page.Items),
+		Total:      page.Total,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+		NextCursor: page.NextCursor,
+	}, nil
 }
 
-func (s *subscriptionQueryService) SumPriceByFilter(ctx context.Context, userID *string, serviceName *string, startDate, endDate time.Time) (int, error) {
+func (s *subscriptionQueryService) Export(ctx context.Context, query model.SubscriptionListQuery, fn func(sub *model.SubscriptionResponse) error) error {
 	log := logger.Get()
-	log.Infof("[QueryService] SumPriceByFilter called | userID=%v serviceName=%v start=%s end=%s",
-		userID, serviceName, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
+	log.Infof("[QueryService] Export called | sort=%s order=%s", query.Sort, query.Order)
+
+	if err := s.resolveServiceFilter(ctx, &query.Filter); err != nil {
+		log.Errorf("[QueryService] Export error | err=%v", err)
+		return err
+	}
 
-	total, err := s.readRepo.SumPriceByFilter(ctx, userID, serviceName, startDate, endDate)
+	count := 0
+	err := s.readRepo.Stream(ctx, query, func(sub *model.Subscription) error {
+		count++
+		return fn(toSubscriptionResponse(sub))
+	})
+	if err != nil {
+		log.Errorf("[QueryService] Export error | count=%d err=%v", count, err)
+		return err
+	}
+
+	log.Infof("[QueryService] Export success | count=%d", count)
+	return nil
+}
+
+func (s *subscriptionQueryService) SumPriceByFilter(ctx context.Context, query model.CostQuery) (*model.Money, error) {
+	log := logger.Get()
+	log.Infof("[QueryService] SumPriceByFilter called | userID=%v serviceName=%v start=%s end=%s mode=%s currency=%s",
+		query.UserID, query.ServiceName, query.StartDate.Format(time.RFC3339), query.EndDate.Format(time.RFC3339), query.Mode, query.TargetCurrency)
+
+	subs, rates, err := s.costInputs(ctx, query)
 	if err != nil {
 		log.Errorf("[QueryService] SumPriceByFilter error | err=%v", err)
-		return 0, err
+		return nil, err
+	}
+
+	var total model.Amount
+	for i := range subs {
+		cost, err := convertedCost(rates, &subs[i], query.StartDate, query.EndDate, query)
+		if err != nil {
+			log.Warnf("[QueryService] SumPriceByFilter conversion error | subscriptionID=%d err=%v", subs[i].ID, err)
+			return nil, err
+		}
+		total += cost
+	}
+
+	log.Infof("[QueryService] SumPriceByFilter success | total=%s %s subscriptions=%d", total, query.TargetCurrency, len(subs))
+	return &model.Money{Amount: total, Currency: query.TargetCurrency}, nil
+}
+
+func (s *subscriptionQueryService) Breakdown(ctx context.Context, query model.CostQuery) (*model.BreakdownResponse, error) {
+	log := logger.Get()
+	log.Infof("[QueryService] Breakdown called | userID=%v serviceName=%v start=%s end=%s mode=%s currency=%s groupBy=%v",
+		query.UserID, query.ServiceName, query.StartDate.Format(time.RFC3339), query.EndDate.Format(time.RFC3339), query.Mode, query.TargetCurrency, query.GroupBy)
+
+	subs, rates, err := s.costInputs(ctx, query)
+	if err != nil {
+		log.Errorf("[QueryService] Breakdown error | err=%v", err)
+		return nil, err
+	}
+
+	byService := slices.Contains(query.GroupBy, model.GroupByServiceName)
+	byUser := slices.Contains(query.GroupBy, model.GroupByUserID)
+
+	res := &model.BreakdownResponse{
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+		Mode:      query.Mode,
+		Currency:  query.TargetCurrency,
+		GroupBy:   query.GroupBy,
+		Buckets:   []model.BreakdownBucket{},
+	}
+
+	for _, month := range billing.Months(query.StartDate, query.EndDate) {
+		bucket := model.BreakdownBucket{Month: month.Start.Format("2006-01")}
+		groups := map[breakdownKey]model.Amount{}
+		var order []breakdownKey
+
+		for i := range subs {
+			cost, err := convertedCost(rates, &subs[i], month.Start, month.End, query)
+			if err != nil {
+				log.Warnf("[QueryService] Breakdown conversion error | subscriptionID=%d err=%v", subs[i].ID, err)
+				return nil, err
+			}
+			if cost == 0 {
+				continue
+			}
+			bucket.Total += cost
+
+			if !byService && !byUser {
+				continue
+			}
+			var key breakdownKey
+			if byService {
+				key.serviceName = subs[i].ServiceName
+			}
+			if byUser {
+				key.userID = subs[i].UserID
+			}
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+			}
+			groups[key] += cost
+		}
+
+		for _, key := range order {
+			group := model.BreakdownGroup{ServiceName: key.serviceName, Total: groups[key]}
+			if byUser {
+				uid := key.userID
+				group.UserID = &uid
+			}
+			bucket.Groups = append(bucket.Groups, group)
+		}
+
+		res.Total += bucket.Total
+		res.Buckets = append(res.Buckets, bucket)
+	}
+
+	log.Infof("[QueryService] Breakdown success | buckets=%d total=%s %s subscriptions=%d", len(res.Buckets), res.Total, res.Currency, len(subs))
+	return res, nil
+}
+
+// GetPriceHistory returns the price history of a subscription, or
+// model.ErrSubscriptionNotFound when the subscription does not exist.
+func (s *subscriptionQueryService) GetPriceHistory(ctx context.Context, id uint) ([]model.SubscriptionPrice, error) {
+	log := logger.Get()
+	log.Infof("[QueryService] GetPriceHistory called | id=%d", id)
+
+	if _, err := s.readRepo.GetByID(ctx, id); err != nil {
+		log.Errorf("[QueryService] GetPriceHistory error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	prices, err := s.priceRepo.GetBySubscriptionID(ctx, id)
+	if err != nil {
+		log.Errorf("[QueryService] GetPriceHistory error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	log.Infof("[QueryService] GetPriceHistory success | id=%d count=%d", id, len(prices))
+	return prices, nil
+}
+
+// GetPauseHistory returns the pauses of a subscription, oldest first, or
+// model.ErrSubscriptionNotFound when the subscription does not exist.
+func (s *subscriptionQueryService) GetPauseHistory(ctx context.Context, id uint) ([]model.SubscriptionPause, error) {
+	log := logger.Get()
+	log.Infof("[QueryService] GetPauseHistory called | id=%d", id)
+
+	if _, err := s.readRepo.GetByID(ctx, id); err != nil {
+		log.Errorf("[QueryService] GetPauseHistory error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	pauses, err := s.pauseRepo.GetBySubscriptionID(ctx, id)
+	if err != nil {
+		log.Errorf("[QueryService] GetPauseHistory error | id=%d err=%v", id, err)
+		return nil, err
 	}
 
-	log.Infof("[QueryService] SumPriceByFilter success | total=%d", int(total))
-	return int(total), nil
+	log.Infof("[QueryService] GetPauseHistory success | id=%d count=%d", id, len(pauses))
+	return pauses, nil
+}
+
+type breakdownKey struct {
+	serviceName string
+	userID      uuid.UUID
+}
+
+func (s *subscriptionQueryService) costInputs(ctx context.Context, query model.CostQuery) ([]model.Subscription, *billing.RateTable, error) {
+	filter := model.SubscriptionFilter{ServiceName: query.ServiceName, UserID: query.UserID}
+	if err := s.resolveServiceFilter(ctx, &filter); err != nil {
+		return nil, nil, err
+	}
+
+	subs, err := s.readRepo.GetOverlapping(ctx, filter, query.StartDate, query.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rates, err := s.rateSvc.RateTable(ctx, query.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subs, rates, nil
+}
+
+// resolveServiceFilter turns a service_name filter that names a catalog entry,
+// or one of its aliases, into a filter by the entry, so that every spelling of
+// the name selects the same subscriptions. Subscriptions not linked to the
+// catalog yet are matched by the entry's name and aliases.
+func (s *subscriptionQueryService) resolveServiceFilter(ctx context.Context, f *model.SubscriptionFilter) error {
+	var svc *model.Service
+	var err error
+	switch {
+	case f.ServiceID != nil:
+		svc, err = s.catalog.Get(ctx, *f.ServiceID)
+		if errors.Is(err, model.ErrServiceNotFound) {
+			return nil
+		}
+	case f.ServiceName != nil && *f.ServiceName != "":
+		svc, err = s.catalog.Resolve(ctx, *f.ServiceName)
+	}
+	if err != nil || svc == nil {
+		return err
+	}
+	filterByService(f, svc)
+	return nil
+}
+
+// filterByService makes f select the subscriptions of svc: those linked to it
+// and the unlinked ones carrying its name or an alias.
+func filterByService(f *model.SubscriptionFilter, svc *model.Service) {
+	id := svc.ID
+	f.ServiceName = nil
+	f.ServiceID = &id
+	f.ServiceNames = svc.Names()
+}
+
+// convertedCost sums the charges of sub inside [from, to] in the target
+// currency, converting each charge at the rate in effect on its billing date.
+func convertedCost(rates *billing.RateTable, sub *model.Subscription, from, to time.Time, query model.CostQuery) (model.Amount, error) {
+	var total model.Amount
+	for _, charge := range billing.Charges(sub, from, to, query.Mode) {
+		amount, err := rates.Convert(charge.Amount, charge.Currency, query.TargetCurrency, charge.Date)
+		if err != nil {
+			return 0, err
+		}
+		total += amount
+	}
+	return total, nil
 }
 
 func toSubscriptionResponse(sub *model.Subscription) *model.SubscriptionResponse {
 	if sub == nil {
 		return nil
 	}
-	return &model.SubscriptionResponse{
-		ID:          sub.ID,
-		ServiceName: sub.ServiceName,
-		Price:		 float64(sub.Price),
-		UserID:      sub.UserID,
-		StartDate:   sub.StartDate,
-		EndDate:     sub.EndDate,
-		CreatedAt:   sub.CreatedAt,
-		UpdatedAt:   sub.UpdatedAt,
+	res := &model.SubscriptionResponse{
+		ID:              sub.ID,
+		ServiceName:     sub.ServiceName,
+		ServiceID:       sub.ServiceID,
+		Price:           sub.Price,
+		Currency:        sub.Currency,
+		UserID:          sub.UserID,
+		StartDate:       sub.StartDate,
+		EndDate:         sub.EndDate,
+		BillingPeriod:   sub.BillingPeriod,
+		BillingInterval: sub.BillingInterval,
+		TrialEndDate:    sub.TrialEndDate,
+		TrialPrice:      sub.TrialPrice,
+		Version:         sub.Version,
+		CreatedAt:       sub.CreatedAt,
+		UpdatedAt:       sub.UpdatedAt,
+	}
+	if sub.DeletedAt.Valid {
+		res.DeletedAt = &sub.DeletedAt.Time
+	}
+	res.Status = sub.Status(time.Now())
+	res.PausedAt = sub.PausedAt
+	res.ResumeAt = sub.ResumeAt
+	res.CanceledAt = sub.CanceledAt
+	return res
+}
+
+// fromSubscriptionResponse is the inverse of toSubscriptionResponse, without
+// the price and pause history.
+func fromSubscriptionResponse(res *model.SubscriptionResponse) *model.Subscription {
+	sub := &model.Subscription{
+		ID:              res.ID,
+		ServiceName:     res.ServiceName,
+		ServiceID:       res.ServiceID,
+		Price:           res.Price,
+		Currency:        res.Currency,
+		UserID:          res.UserID,
+		StartDate:       res.StartDate,
+		EndDate:         res.EndDate,
+		BillingPeriod:   res.BillingPeriod,
+		BillingInterval: res.BillingInterval,
+		TrialEndDate:    res.TrialEndDate,
+		TrialPrice:      res.TrialPrice,
+		Version:         res.Version,
+		CreatedAt:       res.CreatedAt,
+		UpdatedAt:       res.UpdatedAt,
+		PausedAt:        res.PausedAt,
+		ResumeAt:        res.ResumeAt,
+		CanceledAt:      res.CanceledAt,
+	}
+	if res.DeletedAt != nil {
+		sub.DeletedAt = gorm.DeletedAt{Time: *res.DeletedAt, Valid: true}
 	}
+	return sub
 }
 
 func toSubscriptionResponseList(subs []model.Subscription) []model.SubscriptionResponse {
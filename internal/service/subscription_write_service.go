@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/winnamu6/go-subscription-service/internal/billing"
 	"github.com/winnamu6/go-subscription-service/internal/logger"
 	"github.com/winnamu6/go-subscription-service/internal/model"
 	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
@@ -12,40 +13,138 @@ import (
 
 type SubscriptionCommandService interface {
 	Create(ctx context.Context, req *model.CreateSubscriptionRequest) (*model.SubscriptionResponse, error)
-	Update(ctx context.Context, id uint, req *model.UpdateSubscriptionRequest) (*model.SubscriptionResponse, error)
-	Delete(ctx context.Context, id uint) error
+	// Update and Delete check ifMatch, when set, against the current version
+	// of the subscription and fail with model.ErrVersionMismatch otherwise.
+	Update(ctx context.Context, id uint, req *model.UpdateSubscriptionRequest, ifMatch *int) (*model.SubscriptionResponse, error)
+	// Patch builds a full update request from the current state, lets apply
+	// modify it and stores the result. The write only succeeds if the
+	// subscription has not changed since it was read.
+	Patch(ctx context.Context, id uint, apply func(req *model.UpdateSubscriptionRequest) error, ifMatch *int) (*model.SubscriptionResponse, error)
+	// Pause stops billing from now until req.ResumeAt or until Resume is
+	// called; Resume ends the current pause now.
+	Pause(ctx context.Context, id uint, req *model.PauseSubscriptionRequest, ifMatch *int) (*model.SubscriptionResponse, error)
+	Resume(ctx context.Context, id uint, ifMatch *int) (*model.SubscriptionResponse, error)
+	// Cancel ends the subscription now or at the end of its current billing
+	// period and records the reason.
+	Cancel(ctx context.Context, id uint, req *model.CancelSubscriptionRequest, ifMatch *int) (*model.SubscriptionResponse, error)
+	Delete(ctx context.Context, id uint, ifMatch *int) error
+	Restore(ctx context.Context, id uint) (*model.SubscriptionResponse, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+	// ExpireDue emits subscription.expired for subscriptions that ended
+	// before now and returns how many expired.
+	ExpireDue(ctx context.Context, now time.Time) (int, error)
+	// ConvertTrials emits subscription.trial_converted for subscriptions whose
+	// trial ended by now and returns how many were converted.
+	ConvertTrials(ctx context.Context, now time.Time) (int, error)
+	// ApplyDuePrices makes scheduled prices that are in effect at now the
+	// current price of their subscriptions, recording each change like an
+	// update, and returns how many subscriptions changed.
+	ApplyDuePrices(ctx context.Context, now time.Time) (int, error)
 }
 
 type subscriptionCommandService struct {
-	writeRepo write_repository.SubscriptionWriteRepository
-	readSvc   SubscriptionQueryService
+	writeRepo  write_repository.SubscriptionWriteRepository
+	priceRepo  write_repository.SubscriptionPriceWriteRepository
+	pauseRepo  write_repository.SubscriptionPauseWriteRepository
+	cancelRepo write_repository.SubscriptionCancellationWriteRepository
+	auditRepo  write_repository.AuditWriteRepository
+	outbox     write_repository.OutboxWriteRepository
+	tx         write_repository.Transactor
+	readSvc    SubscriptionQueryService
+	catalog    CatalogService
 }
 
-func NewSubscriptionCommandService(writeRepo write_repository.SubscriptionWriteRepository, readSvc SubscriptionQueryService) SubscriptionCommandService {
+func NewSubscriptionCommandService(
+	writeRepo write_repository.SubscriptionWriteRepository,
+	priceRepo write_repository.SubscriptionPriceWriteRepository,
+	pauseRepo write_repository.SubscriptionPauseWriteRepository,
+	cancelRepo write_repository.SubscriptionCancellationWriteRepository,
+	auditRepo write_repository.AuditWriteRepository,
+	outbox write_repository.OutboxWriteRepository,
+	tx write_repository.Transactor,
+	readSvc SubscriptionQueryService,
+	catalog CatalogService,
+) SubscriptionCommandService {
 	return &subscriptionCommandService{
-		writeRepo: writeRepo,
-		readSvc:   readSvc,
+		writeRepo:  writeRepo,
+		priceRepo:  priceRepo,
+		pauseRepo:  pauseRepo,
+		cancelRepo: cancelRepo,
+		auditRepo:  auditRepo,
+		outbox:     outbox,
+		tx:         tx,
+		readSvc:    readSvc,
+		catalog:    catalog,
 	}
 }
 
 func (s *subscriptionCommandService) Create(ctx context.Context, req *model.CreateSubscriptionRequest) (*model.SubscriptionResponse, error) {
 	log := logger.Get()
-	log.Infof("[CommandService] Create called | userID=%s serviceName=%s", req.UserID, req.ServiceName)
+	log.Infof("[CommandService] Create called | userID=%s serviceName=%s serviceID=%v", req.UserID, req.ServiceName, req.ServiceID)
+
+	svc, err := s.resolveService(ctx, req.ServiceID, req.ServiceName)
+	if err != nil {
+		log.Warnf("[CommandService] Create service error | userID=%s err=%v", req.UserID, err)
+		return nil, err
+	}
+	filled := *req
+	req = &filled
+	if err := applyCatalog(svc, req.ServiceID != nil, &req.ServiceName, &req.Price, &req.Currency, &req.BillingPeriod, &req.BillingInterval); err != nil {
+		log.Warnf("[CommandService] Create without price | userID=%s serviceID=%v", req.UserID, req.ServiceID)
+		return nil, err
+	}
+
+	currency := defaultString(req.Currency, model.DefaultCurrency)
+	period, interval, err := normalizeBilling(req.BillingPeriod, req.BillingInterval)
+	if err != nil {
+		log.Warnf("[CommandService] Create custom period without interval | userID=%s", req.UserID)
+		return nil, err
+	}
 
 	if req.EndDate != nil && req.EndDate.Before(req.StartDate) {
 		log.Warnf("[CommandService] Create invalid dates | start=%s end=%s", req.StartDate, req.EndDate)
-		return nil, errors.New("end_date cannot be before start_date")
+		return nil, model.ErrInvalidDateRange
+	}
+	if err := validateTrial(req.StartDate, req.TrialEndDate, req.TrialPrice); err != nil {
+		log.Warnf("[CommandService] Create invalid trial | start=%s trialEnd=%v err=%v", req.StartDate, req.TrialEndDate, err)
+		return nil, err
 	}
 
 	sub := &model.Subscription{
-		ServiceName: req.ServiceName,
-		Price:       int(req.Price),
-		UserID:      req.UserID,
-		StartDate:   req.StartDate,
-		EndDate:     req.EndDate,
+		ServiceName:     req.ServiceName,
+		ServiceID:       serviceID(svc),
+		Price:           req.Price,
+		Currency:        currency,
+		UserID:          req.UserID,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		BillingPeriod:   period,
+		BillingInterval: interval,
+		TrialEndDate:    req.TrialEndDate,
+		TrialPrice:      req.TrialPrice,
 	}
 
-	if err := s.writeRepo.Create(ctx, sub); err != nil {
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.writeRepo.Create(ctx, sub); err != nil {
+			return err
+		}
+		err := s.priceRepo.Upsert(ctx, &model.SubscriptionPrice{
+			SubscriptionID: sub.ID,
+			Amount:         sub.Price,
+			Currency:       sub.Currency,
+			EffectiveFrom:  sub.StartDate,
+		})
+		if err != nil {
+			return err
+		}
+		err = recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, sub.ID, model.AuditActionCreate,
+			nil, toSubscriptionResponse(sub))
+		if err != nil {
+			return err
+		}
+		return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionCreated, toSubscriptionResponse(sub))
+	})
+	if err != nil {
 		log.Errorf("[CommandService] Create error | userID=%s err=%v", req.UserID, err)
 		return nil, err
 	}
@@ -54,7 +153,7 @@ func (s *subscriptionCommandService) Create(ctx context.Context, req *model.Crea
 	return toSubscriptionResponse(sub), nil
 }
 
-func (s *subscriptionCommandService) Update(ctx context.Context, id uint, req *model.UpdateSubscriptionRequest) (*model.SubscriptionResponse, error) {
+func (s *subscriptionCommandService) Update(ctx context.Context, id uint, req *model.UpdateSubscriptionRequest, ifMatch *int) (*model.SubscriptionResponse, error) {
 	log := logger.Get()
 	log.Infof("[CommandService] Update called | id=%d", id)
 
@@ -63,40 +162,305 @@ func (s *subscriptionCommandService) Update(ctx context.Context, id uint, req *m
 		log.Errorf("[CommandService] Update read error | id=%d err=%v", id, err)
 		return nil, err
 	}
-	if existing == nil {
-		log.Warnf("[CommandService] Update failed | id=%d not found", id)
-		return nil, errors.New("subscription not found")
+	if ifMatch != nil && *ifMatch != existing.Version {
+		log.Warnf("[CommandService] Update version mismatch | id=%d version=%d ifMatch=%d", id, existing.Version, *ifMatch)
+		return nil, model.ErrVersionMismatch
 	}
 
-	startDate := coalesceTime(req.StartDate, existing.StartDate)
-	endDate := coalesceTimePtr(req.EndDate, existing.EndDate)
-	if endDate != nil && endDate.Before(startDate) {
-		log.Warnf("[CommandService] Update invalid dates | id=%d start=%s end=%s", id, startDate, *endDate)
-		return nil, errors.New("end_date cannot be before start_date")
+	return s.replace(ctx, existing, req, ifMatch)
+}
+
+func (s *subscriptionCommandService) Patch(ctx context.Context, id uint, apply func(req *model.UpdateSubscriptionRequest) error, ifMatch *int) (*model.SubscriptionResponse, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] Patch called | id=%d", id)
+
+	existing, err := s.readSvc.GetByID(ctx, id)
+	if err != nil {
+		log.Errorf("[CommandService] Patch read error | id=%d err=%v", id, err)
+		return nil, err
+	}
+	if ifMatch != nil && *ifMatch != existing.Version {
+		log.Warnf("[CommandService] Patch version mismatch | id=%d version=%d ifMatch=%d", id, existing.Version, *ifMatch)
+		return nil, model.ErrVersionMismatch
 	}
 
-	price := coalesceFloatToInt(req.Price, int(existing.Price))
+	req := toUpdateSubscriptionRequest(existing)
+	if err := apply(req); err != nil {
+		log.Warnf("[CommandService] Patch apply error | id=%d err=%v", id, err)
+		return nil, err
+	}
+	return s.replace(ctx, existing, req, ifMatch)
+}
+
+// replace stores req as the new state of existing. The write is a
+// compare-and-swap on existing.Version.
+func (s *subscriptionCommandService) replace(ctx context.Context, existing *model.SubscriptionResponse, req *model.UpdateSubscriptionRequest, ifMatch *int) (*model.SubscriptionResponse, error) {
+	log := logger.Get()
+	id := existing.ID
+
+	svc, err := s.resolveService(ctx, req.ServiceID, req.ServiceName)
+	if err != nil {
+		log.Warnf("[CommandService] Update service error | id=%d err=%v", id, err)
+		return nil, err
+	}
+	filled := *req
+	req = &filled
+	if err := applyCatalog(svc, req.ServiceID != nil, &req.ServiceName, &req.Price, &req.Currency, &req.BillingPeriod, &req.BillingInterval); err != nil {
+		log.Warnf("[CommandService] Update without price | id=%d serviceID=%v", id, req.ServiceID)
+		return nil, err
+	}
+
+	if req.EndDate != nil && req.EndDate.Before(req.StartDate) {
+		log.Warnf("[CommandService] Update invalid dates | id=%d start=%s end=%s", id, req.StartDate, *req.EndDate)
+		return nil, model.ErrInvalidDateRange
+	}
+	if err := validateTrial(req.StartDate, req.TrialEndDate, req.TrialPrice); err != nil {
+		log.Warnf("[CommandService] Update invalid trial | id=%d start=%s trialEnd=%v err=%v", id, req.StartDate, req.TrialEndDate, err)
+		return nil, err
+	}
+
+	period, interval, err := normalizeBilling(req.BillingPeriod, req.BillingInterval)
+	if err != nil {
+		log.Warnf("[CommandService] Update custom period without interval | id=%d", id)
+		return nil, err
+	}
+
+	currency := defaultString(req.Currency, model.DefaultCurrency)
+	priceChanged := req.Price != existing.Price || currency != existing.Currency
+	if req.PriceEffectiveFrom != nil && !priceChanged {
+		log.Warnf("[CommandService] Update price_effective_from without price | id=%d", id)
+		return nil, model.ErrPriceEffectiveFromInvalid
+	}
 
 	sub := &model.Subscription{
-		ID:          id,
-		ServiceName: coalesceString(req.ServiceName, existing.ServiceName),
-		Price:       price,
-		UserID:      existing.UserID,
-		StartDate:   startDate,
-		EndDate:     endDate,
-		CreatedAt:   existing.CreatedAt,
+		ID:              id,
+		ServiceName:     req.ServiceName,
+		ServiceID:       serviceID(svc),
+		Price:           existing.Price,
+		Currency:        existing.Currency,
+		UserID:          existing.UserID,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		BillingPeriod:   period,
+		BillingInterval: interval,
+		TrialEndDate:    req.TrialEndDate,
+		TrialPrice:      req.TrialPrice,
+		Version:         existing.Version,
+		CreatedAt:       existing.CreatedAt,
+		PausedAt:        existing.PausedAt,
+		ResumeAt:        existing.ResumeAt,
 	}
+	// removing the end date takes the subscription back from cancellation
+	if req.EndDate != nil {
+		sub.CanceledAt = existing.CanceledAt
+	}
+	reverted := existing.CanceledAt != nil && sub.CanceledAt == nil
 
-	if err := s.writeRepo.Update(ctx, sub); err != nil {
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if reverted {
+			if err := s.cancelRepo.Revert(ctx, id, time.Now()); err != nil {
+				return err
+			}
+		}
+		if priceChanged {
+			if err := s.changePrice(ctx, sub, req.Price, currency, req.PriceEffectiveFrom); err != nil {
+				return err
+			}
+		}
+		if err := s.writeRepo.Update(ctx, sub); err != nil {
+			return err
+		}
+		err := recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, id, model.AuditActionUpdate,
+			existing, toSubscriptionResponse(sub))
+		if err != nil {
+			return err
+		}
+		return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionUpdated, toSubscriptionResponse(sub))
+	})
+	if err != nil {
 		log.Errorf("[CommandService] Update error | id=%d err=%v", id, err)
-		return nil, err
+		return nil, versionError(err, ifMatch)
 	}
 
 	log.Infof("[CommandService] Update success | id=%d", id)
 	return toSubscriptionResponse(sub), nil
 }
 
-func (s *subscriptionCommandService) Delete(ctx context.Context, id uint) error {
+func (s *subscriptionCommandService) Pause(ctx context.Context, id uint, req *model.PauseSubscriptionRequest, ifMatch *int) (*model.SubscriptionResponse, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] Pause called | id=%d resumeAt=%v", id, req.ResumeAt)
+
+	existing, err := s.readSvc.GetByID(ctx, id)
+	if err != nil {
+		log.Errorf("[CommandService] Pause read error | id=%d err=%v", id, err)
+		return nil, err
+	}
+	if ifMatch != nil && *ifMatch != existing.Version {
+		log.Warnf("[CommandService] Pause version mismatch | id=%d version=%d ifMatch=%d", id, existing.Version, *ifMatch)
+		return nil, model.ErrVersionMismatch
+	}
+
+	now := time.Now()
+	switch {
+	case existing.Status == model.SubscriptionStatusEnded:
+		log.Warnf("[CommandService] Pause ended subscription | id=%d", id)
+		return nil, model.ErrSubscriptionEnded
+	case existing.Status == model.SubscriptionStatusPaused:
+		log.Warnf("[CommandService] Pause already paused | id=%d", id)
+		return nil, model.ErrSubscriptionPaused
+	case req.ResumeAt != nil && !req.ResumeAt.After(now):
+		log.Warnf("[CommandService] Pause invalid resume date | id=%d resumeAt=%s", id, *req.ResumeAt)
+		return nil, model.ErrInvalidResumeDate
+	}
+
+	sub := fromSubscriptionResponse(existing)
+	sub.PausedAt = &now
+	sub.ResumeAt = req.ResumeAt
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.writeRepo.SetPause(ctx, sub); err != nil {
+			return err
+		}
+		err := s.pauseRepo.Create(ctx, &model.SubscriptionPause{
+			SubscriptionID: id,
+			PausedAt:       now,
+			ResumeAt:       req.ResumeAt,
+		})
+		if err != nil {
+			return err
+		}
+		err = recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, id, model.AuditActionPause,
+			existing, toSubscriptionResponse(sub))
+		if err != nil {
+			return err
+		}
+		return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionPaused, toSubscriptionResponse(sub))
+	})
+	if err != nil {
+		log.Errorf("[CommandService] Pause error | id=%d err=%v", id, err)
+		return nil, versionError(err, ifMatch)
+	}
+
+	log.Infof("[CommandService] Pause success | id=%d", id)
+	return toSubscriptionResponse(sub), nil
+}
+
+func (s *subscriptionCommandService) Resume(ctx context.Context, id uint, ifMatch *int) (*model.SubscriptionResponse, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] Resume called | id=%d", id)
+
+	existing, err := s.readSvc.GetByID(ctx, id)
+	if err != nil {
+		log.Errorf("[CommandService] Resume read error | id=%d err=%v", id, err)
+		return nil, err
+	}
+	if ifMatch != nil && *ifMatch != existing.Version {
+		log.Warnf("[CommandService] Resume version mismatch | id=%d version=%d ifMatch=%d", id, existing.Version, *ifMatch)
+		return nil, model.ErrVersionMismatch
+	}
+	if existing.Status != model.SubscriptionStatusPaused {
+		log.Warnf("[CommandService] Resume not paused | id=%d status=%s", id, existing.Status)
+		return nil, model.ErrSubscriptionNotPaused
+	}
+
+	now := time.Now()
+	sub := fromSubscriptionResponse(existing)
+	sub.ResumeAt = &now
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.writeRepo.SetPause(ctx, sub); err != nil {
+			return err
+		}
+		if _, err := s.pauseRepo.End(ctx, id, now); err != nil {
+			return err
+		}
+		err := recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, id, model.AuditActionResume,
+			existing, toSubscriptionResponse(sub))
+		if err != nil {
+			return err
+		}
+		return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionResumed, toSubscriptionResponse(sub))
+	})
+	if err != nil {
+		log.Errorf("[CommandService] Resume error | id=%d err=%v", id, err)
+		return nil, versionError(err, ifMatch)
+	}
+
+	log.Infof("[CommandService] Resume success | id=%d", id)
+	return toSubscriptionResponse(sub), nil
+}
+
+func (s *subscriptionCommandService) Cancel(ctx context.Context, id uint, req *model.CancelSubscriptionRequest, ifMatch *int) (*model.SubscriptionResponse, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] Cancel called | id=%d mode=%s reason=%s", id, req.Mode, req.Reason)
+
+	existing, err := s.readSvc.GetByID(ctx, id)
+	if err != nil {
+		log.Errorf("[CommandService] Cancel read error | id=%d err=%v", id, err)
+		return nil, err
+	}
+	if ifMatch != nil && *ifMatch != existing.Version {
+		log.Warnf("[CommandService] Cancel version mismatch | id=%d version=%d ifMatch=%d", id, existing.Version, *ifMatch)
+		return nil, model.ErrVersionMismatch
+	}
+	switch {
+	case existing.Status == model.SubscriptionStatusEnded:
+		log.Warnf("[CommandService] Cancel ended subscription | id=%d", id)
+		return nil, model.ErrSubscriptionEnded
+	case existing.CanceledAt != nil:
+		log.Warnf("[CommandService] Cancel already canceled | id=%d", id)
+		return nil, model.ErrSubscriptionCanceled
+	}
+
+	now := time.Now()
+	sub := fromSubscriptionResponse(existing)
+	end := now
+	if req.Mode == model.CancelModeAtPeriodEnd {
+		end = billing.PeriodEnd(sub, now)
+	}
+	// an end date set earlier still applies, and a subscription that has not
+	// started yet ends before its first period
+	if sub.EndDate != nil && sub.EndDate.Before(end) {
+		end = *sub.EndDate
+	}
+	if end.Before(sub.StartDate) {
+		end = sub.StartDate
+	}
+	sub.EndDate = &end
+	sub.CanceledAt = &now
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.writeRepo.Update(ctx, sub); err != nil {
+			return err
+		}
+		err := s.cancelRepo.Create(ctx, &model.SubscriptionCancellation{
+			SubscriptionID: id,
+			ServiceName:    sub.ServiceName,
+			Mode:           req.Mode,
+			Reason:         req.Reason,
+			Comment:        req.Comment,
+			EndDate:        end,
+		})
+		if err != nil {
+			return err
+		}
+		err = recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, id, model.AuditActionCancel,
+			existing, toSubscriptionResponse(sub))
+		if err != nil {
+			return err
+		}
+		return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionCanceled, toSubscriptionResponse(sub))
+	})
+	if err != nil {
+		log.Errorf("[CommandService] Cancel error | id=%d err=%v", id, err)
+		return nil, versionError(err, ifMatch)
+	}
+
+	log.Infof("[CommandService] Cancel success | id=%d endDate=%s", id, end.Format(time.RFC3339))
+	return toSubscriptionResponse(sub), nil
+}
+
+func (s *subscriptionCommandService) Delete(ctx context.Context, id uint, ifMatch *int) error {
 	log := logger.Get()
 	log.Infof("[CommandService] Delete called | id=%d", id)
 
@@ -105,52 +469,344 @@ func (s *subscriptionCommandService) Delete(ctx context.Context, id uint) error
 		log.Errorf("[CommandService] Delete read error | id=%d err=%v", id, err)
 		return err
 	}
-	if existing == nil {
-		log.Warnf("[CommandService] Delete failed | id=%d not found", id)
-		return errors.New("subscription not found")
+	if ifMatch != nil && *ifMatch != existing.Version {
+		log.Warnf("[CommandService] Delete version mismatch | id=%d version=%d ifMatch=%d", id, existing.Version, *ifMatch)
+		return model.ErrVersionMismatch
 	}
 
-	if err := s.writeRepo.Delete(ctx, id); err != nil {
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.writeRepo.Delete(ctx, id, existing.Version); err != nil {
+			return err
+		}
+		err := recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, id, model.AuditActionDelete,
+			existing, nil)
+		if err != nil {
+			return err
+		}
+		return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionDeleted, existing)
+	})
+	if err != nil {
 		log.Errorf("[CommandService] Delete error | id=%d err=%v", id, err)
-		return err
+		return versionError(err, ifMatch)
 	}
 
 	log.Infof("[CommandService] Delete success | id=%d", id)
 	return nil
 }
 
-func coalesceString(newVal, oldVal string) string {
-	if newVal != "" {
-		return newVal
+// Restore undoes a soft delete.
+func (s *subscriptionCommandService) Restore(ctx context.Context, id uint) (*model.SubscriptionResponse, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] Restore called | id=%d", id)
+
+	deleted, err := s.readSvc.GetDeletedByID(ctx, id)
+	if err != nil {
+		log.Errorf("[CommandService] Restore read error | id=%d err=%v", id, err)
+		return nil, err
+	}
+
+	restored := *deleted
+	restored.DeletedAt = nil
+	restored.Version++
+	restored.UpdatedAt = time.Now()
+
+	var ok bool
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if ok, err = s.writeRepo.Restore(ctx, id); err != nil || !ok {
+			return err
+		}
+		err = recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, id, model.AuditActionRestore,
+			deleted, &restored)
+		if err != nil {
+			return err
+		}
+		return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionRestored, &restored)
+	})
+	if err != nil {
+		log.Errorf("[CommandService] Restore error | id=%d err=%v", id, err)
+		return nil, err
+	}
+	if !ok {
+		log.Warnf("[CommandService] Restore failed | id=%d already restored", id)
+		return nil, model.ErrDeletedSubscriptionNotFound
+	}
+
+	log.Infof("[CommandService] Restore success | id=%d", id)
+	return &restored, nil
+}
+
+// PurgeDeleted permanently removes subscriptions soft-deleted before the given
+// time and returns how many were removed.
+func (s *subscriptionCommandService) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] PurgeDeleted called | before=%s", before.Format(time.RFC3339))
+
+	var purged []model.Subscription
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if purged, err = s.writeRepo.PurgeDeleted(ctx, before); err != nil {
+			return err
+		}
+		for i := range purged {
+			err := recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, purged[i].ID, model.AuditActionPurge,
+				toSubscriptionResponse(&purged[i]), nil)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[CommandService] PurgeDeleted error | err=%v", err)
+		return 0, err
+	}
+
+	log.Infof("[CommandService] PurgeDeleted success | purged=%d", len(purged))
+	return len(purged), nil
+}
+
+func (s *subscriptionCommandService) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] ExpireDue called | now=%s", now.Format(time.RFC3339))
+
+	var expired []model.Subscription
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if expired, err = s.writeRepo.MarkExpired(ctx, now); err != nil {
+			return err
+		}
+		for i := range expired {
+			if err := recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionExpired, toSubscriptionResponse(&expired[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[CommandService] ExpireDue error | err=%v", err)
+		return 0, err
+	}
+
+	log.Infof("[CommandService] ExpireDue success | expired=%d", len(expired))
+	return len(expired), nil
+}
+
+func (s *subscriptionCommandService) ConvertTrials(ctx context.Context, now time.Time) (int, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] ConvertTrials called | now=%s", now.Format(time.RFC3339))
+
+	var converted []model.Subscription
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if converted, err = s.writeRepo.MarkTrialsEnded(ctx, now); err != nil {
+			return err
+		}
+		for i := range converted {
+			if err := recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionTrialConverted, toSubscriptionResponse(&converted[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[CommandService] ConvertTrials error | err=%v", err)
+		return 0, err
+	}
+
+	log.Infof("[CommandService] ConvertTrials success | converted=%d", len(converted))
+	return len(converted), nil
+}
+
+func (s *subscriptionCommandService) ApplyDuePrices(ctx context.Context, now time.Time) (int, error) {
+	log := logger.Get()
+	log.Infof("[CommandService] ApplyDuePrices called | now=%s", now.Format(time.RFC3339))
+
+	due, err := s.priceRepo.ListDue(ctx, now)
+	if err != nil {
+		log.Errorf("[CommandService] ApplyDuePrices list error | err=%v", err)
+		return 0, err
+	}
+
+	applied := 0
+	for _, price := range due {
+		existing, err := s.readSvc.GetByID(ctx, price.SubscriptionID)
+		if errors.Is(err, model.ErrSubscriptionNotFound) {
+			continue
+		}
+		if err != nil {
+			log.Errorf("[CommandService] ApplyDuePrices read error | id=%d err=%v", price.SubscriptionID, err)
+			return applied, err
+		}
+
+		sub := fromSubscriptionResponse(existing)
+		sub.Price = price.Amount
+		sub.Currency = price.Currency
+
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.writeRepo.SetPrice(ctx, sub); err != nil {
+				return err
+			}
+			after := toSubscriptionResponse(sub)
+			if err := recordAudit(ctx, s.auditRepo, model.AuditEntitySubscription, sub.ID, model.AuditActionUpdate, existing, after); err != nil {
+				return err
+			}
+			return recordSubscriptionEvent(ctx, s.outbox, model.EventSubscriptionUpdated, after)
+		})
+		if errors.Is(err, model.ErrConcurrentUpdate) {
+			// changed in the meantime; the next run picks it up again
+			log.Warnf("[CommandService] ApplyDuePrices skipped concurrently updated subscription | id=%d", sub.ID)
+			continue
+		}
+		if err != nil {
+			log.Errorf("[CommandService] ApplyDuePrices error | id=%d err=%v", sub.ID, err)
+			return applied, err
+		}
+		applied++
+	}
+
+	log.Infof("[CommandService] ApplyDuePrices success | applied=%d", applied)
+	return applied, nil
+}
+
+// changePrice records a price history entry and refreshes the current price
+// of sub. A change effective in the future only becomes the current price
+// once its date is reached (see ApplyDuePrices).
+func (s *subscriptionCommandService) changePrice(ctx context.Context, sub *model.Subscription, amount model.Amount, currency string, from *time.Time) error {
+	log := logger.Get()
+
+	now := time.Now()
+	effectiveFrom := now
+	if from != nil {
+		effectiveFrom = *from
+	}
+
+	price := &model.SubscriptionPrice{
+		SubscriptionID: sub.ID,
+		Amount:         amount,
+		Currency:       currency,
+		EffectiveFrom:  effectiveFrom,
+	}
+	if err := s.priceRepo.Upsert(ctx, price); err != nil {
+		return err
+	}
+
+	at := now
+	if sub.StartDate.After(at) {
+		at = sub.StartDate
+	}
+	current, err := s.priceRepo.GetEffective(ctx, sub.ID, at)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		sub.Price = current.Amount
+		sub.Currency = current.Currency
+	}
+
+	log.Infof("[CommandService] Price change recorded | id=%d price=%s %s effectiveFrom=%s",
+		sub.ID, price.Amount, price.Currency, effectiveFrom.Format(time.RFC3339))
+	return nil
+}
+
+func toUpdateSubscriptionRequest(sub *model.SubscriptionResponse) *model.UpdateSubscriptionRequest {
+	return &model.UpdateSubscriptionRequest{
+		ServiceName:     sub.ServiceName,
+		Price:           sub.Price,
+		Currency:        sub.Currency,
+		StartDate:       sub.StartDate,
+		EndDate:         sub.EndDate,
+		BillingPeriod:   sub.BillingPeriod,
+		BillingInterval: sub.BillingInterval,
+		TrialEndDate:    sub.TrialEndDate,
+		TrialPrice:      sub.TrialPrice,
+		ServiceID:       sub.ServiceID,
+	}
+}
+
+// resolveService returns the catalog entry a subscription refers to: the one
+// with serviceID when it is set, otherwise the one named or aliased name, if
+// any.
+func (s *subscriptionCommandService) resolveService(ctx context.Context, serviceID *uint, name string) (*model.Service, error) {
+	if serviceID == nil {
+		return s.catalog.Resolve(ctx, name)
+	}
+	svc, err := s.catalog.Get(ctx, *serviceID)
+	if errors.Is(err, model.ErrServiceNotFound) {
+		return nil, model.ErrUnknownService
+	}
+	return svc, err
+}
+
+// applyCatalog gives a subscription of svc the canonical service name. When
+// the entry was referenced by ID, an omitted price, currency and billing
+// period are taken from its defaults.
+func applyCatalog(svc *model.Service, explicit bool, name *string, price *model.Amount, currency, period *string, interval *int) error {
+	if svc == nil {
+		return nil
+	}
+	*name = svc.Name
+	if !explicit {
+		return nil
+	}
+	if *price == 0 {
+		if svc.DefaultPrice == 0 {
+			return model.ErrPriceRequired
+		}
+		*price = svc.DefaultPrice
+		*currency = defaultString(*currency, svc.DefaultCurrency)
 	}
-	return oldVal
+	if *period == "" {
+		*period = svc.DefaultBillingPeriod
+		*interval = svc.DefaultBillingInterval
+	}
+	return nil
 }
 
-func coalesceInt(newVal *int, oldVal int) int {
-	if newVal != nil {
-		return *newVal
+func serviceID(svc *model.Service) *uint {
+	if svc == nil {
+		return nil
 	}
-	return oldVal
+	return &svc.ID
 }
 
-func coalesceFloatToInt(newVal *float64, oldVal int) int {
-	if newVal != nil {
-		return int(*newVal)
+// versionError reports a lost compare-and-swap as a failed precondition when
+// the client asked for a specific version.
+func versionError(err error, ifMatch *int) error {
+	if ifMatch != nil && errors.Is(err, model.ErrConcurrentUpdate) {
+		return model.ErrVersionMismatch
 	}
-	return oldVal
+	return err
 }
 
+// validateTrial checks the trial of a subscription starting at start: it must
+// end after the start, and a trial price needs a trial.
+func validateTrial(start time.Time, trialEnd *time.Time, trialPrice model.Amount) error {
+	if trialEnd != nil && !trialEnd.After(start) {
+		return model.ErrInvalidTrialPeriod
+	}
+	if trialEnd == nil && trialPrice != 0 {
+		return model.ErrTrialPriceWithoutTrial
+	}
+	return nil
+}
 
-func coalesceTime(newVal *time.Time, oldVal time.Time) time.Time {
-	if newVal != nil {
-		return *newVal
+// normalizeBilling applies the billing defaults shared by create and full
+// update: monthly period and an interval of 1, which custom periods must set.
+func normalizeBilling(period string, interval int) (string, int, error) {
+	period = defaultString(period, model.BillingPeriodMonthly)
+	if period == model.BillingPeriodCustom && interval == 0 {
+		return "", 0, model.ErrBillingIntervalRequired
 	}
-	return oldVal
+	if interval == 0 {
+		interval = 1
+	}
+	return period, interval, nil
 }
 
-func coalesceTimePtr(newVal, oldVal *time.Time) *time.Time {
-	if newVal != nil {
-		return newVal
+func defaultString(val, def string) string {
+	if val != "" {
+		return val
 	}
-	return oldVal
-}
\ No newline at end of file
+	return def
+}
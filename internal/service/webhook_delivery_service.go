@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+	"github.com/winnamu6/go-subscription-service/internal/webhook"
+)
+
+const webhookBatchSize = 20
+
+type WebhookDeliveryService interface {
+	// DeliverDue sends the pending deliveries due at now and returns how many
+	// were attempted. Failed attempts are retried with exponential backoff
+	// until maxAttempts is reached.
+	DeliverDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type webhookDeliveryService struct {
+	readRepo    read_repository.WebhookReadRepository
+	writeRepo   write_repository.WebhookWriteRepository
+	client      *webhook.Client
+	timeout     time.Duration
+	maxAttempts int
+}
+
+func NewWebhookDeliveryService(
+	readRepo read_repository.WebhookReadRepository,
+	writeRepo write_repository.WebhookWriteRepository,
+	timeout time.Duration,
+	maxAttempts int,
+) WebhookDeliveryService {
+	return &webhookDeliveryService{
+		readRepo:    readRepo,
+		writeRepo:   writeRepo,
+		client:      webhook.NewClient(timeout),
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (s *webhookDeliveryService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	log := logger.Get()
+	log.Infof("[WebhookDeliveryService] DeliverDue called | now=%s", now.Format(time.RFC3339))
+
+	// A batch is sent one delivery at a time, so the lease must outlast all of
+	// them timing out.
+	lease := webhookBatchSize*s.timeout + time.Minute
+	endpoints := make(map[uint]*model.WebhookEndpoint)
+
+	var attempted int
+	for ctx.Err() == nil {
+		batch, err := s.writeRepo.ClaimDue(ctx, now, lease, webhookBatchSize)
+		if err != nil {
+			log.Errorf("[WebhookDeliveryService] DeliverDue claim error | err=%v", err)
+			return attempted, err
+		}
+
+		for i := range batch {
+			if err := s.deliver(ctx, &batch[i], endpoints); err != nil {
+				log.Errorf("[WebhookDeliveryService] DeliverDue error | deliveryID=%d err=%v", batch[i].ID, err)
+				return attempted, err
+			}
+			attempted++
+		}
+
+		if len(batch) < webhookBatchSize {
+			break
+		}
+	}
+
+	log.Infof("[WebhookDeliveryService] DeliverDue success | attempted=%d", attempted)
+	return attempted, nil
+}
+
+// deliver makes one attempt to send d and records its outcome. Deliveries to
+// deleted endpoints fail without being sent.
+func (s *webhookDeliveryService) deliver(ctx context.Context, d *model.WebhookDelivery, endpoints map[uint]*model.WebhookEndpoint) error {
+	log := logger.Get()
+
+	endpoint, ok := endpoints[d.EndpointID]
+	if !ok {
+		var err error
+		endpoint, err = s.readRepo.GetEndpoint(ctx, d.EndpointID)
+		if err != nil && !errors.Is(err, model.ErrWebhookNotFound) {
+			return err
+		}
+		endpoints[d.EndpointID] = endpoint
+	}
+	if endpoint == nil {
+		d.Status = model.WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		d.LastError = "webhook endpoint was deleted"
+		return s.writeRepo.SaveAttempt(ctx, d)
+	}
+
+	attemptAt := time.Now()
+	status, sendErr := s.client.Send(ctx, endpoint.URL, endpoint.Secret, d, attemptAt)
+	if ctx.Err() != nil {
+		// shutting down: the delivery is picked up again once its lease expires
+		return nil
+	}
+
+	d.Attempts++
+	d.LastAttemptAt = &attemptAt
+	d.ResponseStatus = status
+	d.NextAttemptAt = nil
+	switch {
+	case sendErr == nil:
+		d.Status = model.WebhookDeliverySucceeded
+		d.LastError = ""
+		log.Infof("[WebhookDeliveryService] Delivered | deliveryID=%d endpointID=%d status=%d", d.ID, d.EndpointID, status)
+	case d.Attempts >= s.maxAttempts:
+		d.Status = model.WebhookDeliveryFailed
+		d.LastError = sendErr.Error()
+		log.Warnf("[WebhookDeliveryService] Delivery failed | deliveryID=%d attempts=%d err=%v", d.ID, d.Attempts, sendErr)
+	default:
+		next := attemptAt.Add(webhook.Backoff(d.Attempts))
+		d.NextAttemptAt = &next
+		d.LastError = sendErr.Error()
+		log.Warnf("[WebhookDeliveryService] Delivery will be retried | deliveryID=%d attempts=%d next=%s err=%v",
+			d.ID, d.Attempts, next.Format(time.RFC3339), sendErr)
+	}
+	return s.writeRepo.SaveAttempt(ctx, d)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/logger"
+	"github.com/winnamu6/go-subscription-service/internal/model"
+	"github.com/winnamu6/go-subscription-service/internal/repository/read_repository"
+	"github.com/winnamu6/go-subscription-service/internal/repository/write_repository"
+)
+
+type WebhookService interface {
+	// Enqueue queues an outbox message for every endpoint subscribed to its
+	// event type. It is subscribed to the event bus; a message published
+	// twice is only queued once per endpoint.
+	Enqueue(ctx context.Context, msg *model.OutboxMessage) error
+	CreateEndpoint(ctx context.Context, req *model.CreateWebhookRequest) (*model.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]model.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id uint) (*model.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id uint) error
+	ListDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) (*model.WebhookDeliveryListResponse, error)
+	// Replay queues a copy of a delivery to be sent again, regardless of the
+	// outcome of the original.
+	Replay(ctx context.Context, endpointID, deliveryID uint) (*model.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	readRepo  read_repository.WebhookReadRepository
+	writeRepo write_repository.WebhookWriteRepository
+}
+
+func NewWebhookService(readRepo read_repository.WebhookReadRepository, writeRepo write_repository.WebhookWriteRepository) WebhookService {
+	return &webhookService{readRepo: readRepo, writeRepo: writeRepo}
+}
+
+func (s *webhookService) Enqueue(ctx context.Context, msg *model.OutboxMessage) error {
+	log := logger.Get()
+	log.Infof("[WebhookService] Enqueue called | eventType=%s eventID=%s", msg.EventType, msg.EventID)
+
+	endpoints, err := s.readRepo.EndpointsFor(ctx, msg.EventType)
+	if err != nil {
+		log.Errorf("[WebhookService] Enqueue error | eventID=%s err=%v", msg.EventID, err)
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]model.WebhookDelivery, len(endpoints))
+	for i, endpoint := range endpoints {
+		deliveries[i] = model.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			EventID:       msg.EventID,
+			EventType:     msg.EventType,
+			Payload:       msg.Payload,
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+	}
+	if err := s.writeRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		log.Errorf("[WebhookService] Enqueue error | eventID=%s err=%v", msg.EventID, err)
+		return err
+	}
+
+	log.Infof("[WebhookService] Enqueue success | eventID=%s endpoints=%d", msg.EventID, len(endpoints))
+	return nil
+}
+
+func (s *webhookService) CreateEndpoint(ctx context.Context, req *model.CreateWebhookRequest) (*model.WebhookEndpoint, error) {
+	log := logger.Get()
+	log.Infof("[WebhookService] CreateEndpoint called | url=%s events=%v", req.URL, req.EventTypes)
+
+	endpoint := &model.WebhookEndpoint{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: uniqueStrings(req.EventTypes),
+	}
+	if err := s.writeRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		log.Errorf("[WebhookService] CreateEndpoint error | url=%s err=%v", req.URL, err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookService] CreateEndpoint success | id=%d", endpoint.ID)
+	return endpoint, nil
+}
+
+func (s *webhookService) ListEndpoints(ctx context.Context) ([]model.WebhookEndpoint, error) {
+	return s.readRepo.ListEndpoints(ctx)
+}
+
+func (s *webhookService) GetEndpoint(ctx context.Context, id uint) (*model.WebhookEndpoint, error) {
+	return s.readRepo.GetEndpoint(ctx, id)
+}
+
+func (s *webhookService) DeleteEndpoint(ctx context.Context, id uint) error {
+	return s.writeRepo.DeleteEndpoint(ctx, id)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) (*model.WebhookDeliveryListResponse, error) {
+	log := logger.Get()
+	log.Infof("[WebhookService] ListDeliveries called | filter=%+v", filter)
+
+	if _, err := s.readRepo.GetEndpoint(ctx, filter.EndpointID); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = model.DefaultPageLimit
+	}
+
+	deliveries, total, err := s.readRepo.ListDeliveries(ctx, filter)
+	if err != nil {
+		log.Errorf("[WebhookService] ListDeliveries error | err=%v", err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookService] ListDeliveries success | count=%d total=%d", len(deliveries), total)
+	return &model.WebhookDeliveryListResponse{
+		Items:  deliveries,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+func (s *webhookService) Replay(ctx context.Context, endpointID, deliveryID uint) (*model.WebhookDelivery, error) {
+	log := logger.Get()
+	log.Infof("[WebhookService] Replay called | endpointID=%d deliveryID=%d", endpointID, deliveryID)
+
+	if _, err := s.readRepo.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+	original, err := s.readRepo.GetDelivery(ctx, endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	replay := []model.WebhookDelivery{{
+		EndpointID:    original.EndpointID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf:      &original.ID,
+	}}
+	if err := s.writeRepo.CreateDeliveries(ctx, replay); err != nil {
+		log.Errorf("[WebhookService] Replay error | deliveryID=%d err=%v", deliveryID, err)
+		return nil, err
+	}
+
+	log.Infof("[WebhookService] Replay success | deliveryID=%d replayID=%d", deliveryID, replay[0].ID)
+	return &replay[0], nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
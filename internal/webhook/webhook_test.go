@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/winnamu6/go-subscription-service/internal/model"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{
+			name:   "known vector",
+			secret: "secret", timestamp: 1735689600, body: `{"a":1}`,
+			want: "sha256=ab462121ddac84e96a964823c00d2a9bb31b704cd12b07607df936b988230a64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignDependsOnEveryInput(t *testing.T) {
+	base := Sign("secret", 1735689600, []byte(`{"a":1}`))
+	tests := []struct {
+		name string
+		got  string
+	}{
+		{name: "secret", got: Sign("other", 1735689600, []byte(`{"a":1}`))},
+		{name: "timestamp", got: Sign("secret", 1735689601, []byte(`{"a":1}`))},
+		{name: "body", got: Sign("secret", 1735689600, []byte(`{"a":2}`))},
+		// "<timestamp>.<body>" must not let digits move between the two parts.
+		{name: "timestamp and body boundary", got: Sign("secret", 173568960, []byte(`0{"a":1}`))},
+	}
+	for _, tt := range tests {
+		if tt.got == base {
+			t.Errorf("changing the %s did not change the signature", tt.name)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 30 * time.Second},
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 10, want: 256 * time.Minute},
+		{attempts: 11, want: 6 * time.Hour},
+		{attempts: 12, want: 6 * time.Hour},
+		{attempts: 1000, want: 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	delivery := &model.WebhookDelivery{ID: 42, EventType: model.EventSubscriptionCreated, Payload: []byte(`{"a":1}`)}
+
+	tests := []struct {
+		name     string
+		status   int
+		wantCode int
+		wantErr  bool
+	}{
+		{name: "ok", status: http.StatusOK, wantCode: http.StatusOK},
+		{name: "no content", status: http.StatusNoContent, wantCode: http.StatusNoContent},
+		{name: "redirect is a failure", status: http.StatusFound, wantCode: http.StatusFound, wantErr: true},
+		{name: "server error", status: http.StatusInternalServerError, wantCode: http.StatusInternalServerError, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+				if timestamp != now.Unix() {
+					t.Errorf("%s = %d, want %d", HeaderTimestamp, timestamp, now.Unix())
+				}
+				if got, want := r.Header.Get(HeaderSignature), Sign("secret", timestamp, delivery.Payload); got != want {
+					t.Errorf("%s = %s, want %s", HeaderSignature, got, want)
+				}
+				if got := r.Header.Get(HeaderEvent); got != delivery.EventType {
+					t.Errorf("%s = %s, want %s", HeaderEvent, got, delivery.EventType)
+				}
+				if got := r.Header.Get(HeaderDelivery); got != "42" {
+					t.Errorf("%s = %s, want 42", HeaderDelivery, got)
+				}
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			code, err := NewClient(time.Second).Send(context.Background(), srv.URL, "secret", delivery, now)
+			if code != tt.wantCode {
+				t.Errorf("Send() status = %d, want %d", code, tt.wantCode)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}